@@ -0,0 +1,352 @@
+package whilst
+
+import (
+	"math"
+	"time"
+
+	"github.com/akramarenkov/whilst/internal/credible"
+
+	"github.com/akramarenkov/intspec"
+	"github.com/akramarenkov/safe"
+)
+
+// Components of the duration with signs.
+type signed struct {
+	years  int64
+	months int64
+	weeks  int64
+	days   int64
+	nano   int64
+}
+
+func (whl Whilst) signed() signed {
+	whl = whl.normalize()
+
+	sgn := signed{
+		years:  int64(whl.Years),
+		months: int64(whl.Months),
+		weeks:  int64(whl.Weeks),
+		days:   int64(whl.Days),
+		nano:   int64(whl.Nano),
+	}
+
+	if whl.Negative {
+		sgn.years = -sgn.years
+		sgn.months = -sgn.months
+		sgn.weeks = -sgn.weeks
+		sgn.days = -sgn.days
+	}
+
+	return sgn
+}
+
+// Converts components with signs to the duration.
+//
+// If years and months have different signs, then years are converted to months, and
+// if weeks and days have different signs, then weeks are converted to days, because
+// such conversions are exact. Other components are not converted to each other, so
+// they must have the same sign, otherwise ErrMixedSigns is returned.
+func (sgn signed) whilst() (Whilst, error) {
+	if (sgn.years < 0 && sgn.months > 0) || (sgn.years > 0 && sgn.months < 0) {
+		months := sgn.years*monthsInYear + sgn.months
+
+		sgn.years = months / monthsInYear
+		sgn.months = months % monthsInYear
+	}
+
+	if (sgn.weeks < 0 && sgn.days > 0) || (sgn.weeks > 0 && sgn.days < 0) {
+		days := sgn.weeks*daysInWeek + sgn.days
+
+		sgn.weeks = days / daysInWeek
+		sgn.days = days % daysInWeek
+	}
+
+	negative := sgn.years < 0 || sgn.months < 0 || sgn.weeks < 0 || sgn.days < 0 ||
+		sgn.nano < 0
+	positive := sgn.years > 0 || sgn.months > 0 || sgn.weeks > 0 || sgn.days > 0 ||
+		sgn.nano > 0
+
+	if negative && positive {
+		return Whilst{}, ErrMixedSigns
+	}
+
+	years := safe.Abs(sgn.years)
+	months := safe.Abs(sgn.months)
+	weeks := safe.Abs(sgn.weeks)
+	days := safe.Abs(sgn.days)
+
+	if years > intspec.MaxUint16 || months > intspec.MaxUint16 ||
+		weeks > intspec.MaxUint16 || days > intspec.MaxUint16 {
+		return Whilst{}, safe.ErrOverflow
+	}
+
+	whl := Whilst{
+		Nano:     time.Duration(sgn.nano),
+		Days:     uint16(days),
+		Weeks:    uint16(weeks),
+		Months:   uint16(months),
+		Years:    uint16(years),
+		Negative: negative,
+	}
+
+	return whl, nil
+}
+
+// Returns the sum of the duration and the other duration.
+//
+// Panics if the sum cannot be represented, see [Whilst.AddChecked].
+func (whl Whilst) Add(other Whilst) Whilst {
+	sum, err := whl.AddChecked(other)
+	if err != nil {
+		panic(err)
+	}
+
+	return sum
+}
+
+// Returns the sum of the duration and the other duration.
+//
+// Components of the durations are added separately. Since a month and a day do not
+// have a fixed length, the sum can be represented only if its days together with
+// weeks, nanoseconds and months together with years have the same sign, otherwise
+// [ErrMixedSigns] is returned, e.g. 1y + -3mo = 9mo and 1w + -1d = 6d, but 1mo + -1d
+// cannot be represented. If any of the components overflows, then [safe.ErrOverflow]
+// is returned.
+func (whl Whilst) AddChecked(other Whilst) (Whilst, error) {
+	first := whl.signed()
+	second := other.signed()
+
+	nano, err := safe.Add(first.nano, second.nano)
+	if err != nil {
+		return Whilst{}, err
+	}
+
+	sum := signed{
+		years:  first.years + second.years,
+		months: first.months + second.months,
+		weeks:  first.weeks + second.weeks,
+		days:   first.days + second.days,
+		nano:   nano,
+	}
+
+	return sum.whilst()
+}
+
+// Returns the difference between the duration and the other duration.
+//
+// Panics if the difference cannot be represented, see [Whilst.SubChecked].
+func (whl Whilst) Sub(other Whilst) Whilst {
+	diff, err := whl.SubChecked(other)
+	if err != nil {
+		panic(err)
+	}
+
+	return diff
+}
+
+// Returns the difference between the duration and the other duration.
+//
+// Limitations are the same as for [Whilst.AddChecked].
+func (whl Whilst) SubChecked(other Whilst) (Whilst, error) {
+	first := whl.signed()
+	second := other.signed()
+
+	nano, err := safe.Sub(first.nano, second.nano)
+	if err != nil {
+		return Whilst{}, err
+	}
+
+	diff := signed{
+		years:  first.years - second.years,
+		months: first.months - second.months,
+		weeks:  first.weeks - second.weeks,
+		days:   first.days - second.days,
+		nano:   nano,
+	}
+
+	return diff.whilst()
+}
+
+// Returns the duration with the opposite sign.
+//
+// As a special case, if the nanoseconds are equal to [math.MinInt64], they are
+// converted to [math.MaxInt64], see [Whilst.NegChecked].
+func (whl Whilst) Neg() Whilst {
+	negated, err := whl.NegChecked()
+	if err != nil {
+		negated = whl.normalize()
+		negated.Nano = math.MaxInt64
+		negated.Negative = false
+	}
+
+	return negated
+}
+
+// Returns the duration with the opposite sign.
+//
+// If the nanoseconds are equal to [math.MinInt64], then [safe.ErrOverflow] is
+// returned.
+func (whl Whilst) NegChecked() (Whilst, error) {
+	if whl.IsZero() {
+		return Whilst{}, nil
+	}
+
+	whl = whl.normalize()
+
+	nano, err := safe.Negate(whl.Nano)
+	if err != nil {
+		return Whilst{}, err
+	}
+
+	whl.Nano = nano
+	whl.Negative = !whl.Negative
+
+	return whl, nil
+}
+
+// Returns the absolute value of the duration.
+//
+// As a special case, if the nanoseconds are equal to [math.MinInt64], they are
+// converted to [math.MaxInt64], see [Whilst.AbsChecked].
+func (whl Whilst) Abs() Whilst {
+	if whl.IsNegative() {
+		return whl.Neg()
+	}
+
+	if whl.IsZero() {
+		return Whilst{}
+	}
+
+	return whl.normalize()
+}
+
+// Returns the absolute value of the duration.
+//
+// If the nanoseconds are equal to [math.MinInt64], then [safe.ErrOverflow] is
+// returned.
+func (whl Whilst) AbsChecked() (Whilst, error) {
+	if whl.IsNegative() {
+		return whl.NegChecked()
+	}
+
+	if whl.IsZero() {
+		return Whilst{}, nil
+	}
+
+	return whl.normalize(), nil
+}
+
+// Returns the duration multiplied by the factor.
+//
+// Panics if the product cannot be represented, see [Whilst.MulChecked].
+func (whl Whilst) Mul(factor int) Whilst {
+	product, err := whl.MulChecked(factor)
+	if err != nil {
+		panic(err)
+	}
+
+	return product
+}
+
+// Returns the duration multiplied by the factor.
+//
+// Each of the components is multiplied separately. If any of the components
+// overflows, then [safe.ErrOverflow] is returned.
+func (whl Whilst) MulChecked(factor int) (Whilst, error) {
+	if whl.IsZero() || factor == 0 {
+		return Whilst{}, nil
+	}
+
+	whl = whl.normalize()
+
+	magnitude := safe.Abs(factor)
+
+	years, err := credible.MulU16ByU64(whl.Years, magnitude)
+	if err != nil {
+		return Whilst{}, err
+	}
+
+	months, err := credible.MulU16ByU64(whl.Months, magnitude)
+	if err != nil {
+		return Whilst{}, err
+	}
+
+	weeks, err := credible.MulU16ByU64(whl.Weeks, magnitude)
+	if err != nil {
+		return Whilst{}, err
+	}
+
+	days, err := credible.MulU16ByU64(whl.Days, magnitude)
+	if err != nil {
+		return Whilst{}, err
+	}
+
+	nano, err := safe.Mul(int64(whl.Nano), int64(factor))
+	if err != nil {
+		return Whilst{}, err
+	}
+
+	product := Whilst{
+		Nano:     time.Duration(nano),
+		Days:     days,
+		Weeks:    weeks,
+		Months:   months,
+		Years:    years,
+		Negative: whl.Negative != (factor < 0),
+	}
+
+	return product, nil
+}
+
+// Returns the duration divided by the divisor.
+//
+// Division is performed exactly: the remainders of years and weeks are converted to
+// months and days respectively, because such conversions are exact, and the
+// remainders of months, days and nanoseconds are not allowed, since a month and a day
+// do not have a fixed length. E.g. 1y / 4 = 3mo and 1w / 7 = 1d, but for 1y / 5 and
+// 1mo / 2 [ErrInexactDivision] is returned.
+//
+// If the divisor is zero, then [safe.ErrDivisionByZero] is returned.
+func (whl Whilst) Div(divisor int) (Whilst, error) {
+	if divisor == 0 {
+		return Whilst{}, safe.ErrDivisionByZero
+	}
+
+	if whl.IsZero() {
+		return Whilst{}, nil
+	}
+
+	whl = whl.normalize()
+
+	magnitude := safe.Abs(divisor)
+
+	years := uint64(whl.Years) / magnitude
+	months := uint64(whl.Years)%magnitude*monthsInYear + uint64(whl.Months)
+
+	weeks := uint64(whl.Weeks) / magnitude
+	days := uint64(whl.Weeks)%magnitude*daysInWeek + uint64(whl.Days)
+
+	if months%magnitude != 0 || days%magnitude != 0 {
+		return Whilst{}, ErrInexactDivision
+	}
+
+	if int64(whl.Nano)%int64(divisor) != 0 {
+		return Whilst{}, ErrInexactDivision
+	}
+
+	nano, err := safe.Div(int64(whl.Nano), int64(divisor))
+	if err != nil {
+		return Whilst{}, err
+	}
+
+	quotient := Whilst{
+		Nano:     time.Duration(nano),
+		Days:     uint16(days / magnitude),
+		Weeks:    uint16(weeks),
+		Months:   uint16(months / magnitude),
+		Years:    uint16(years),
+		Negative: whl.Negative != (divisor < 0),
+	}
+
+	return quotient, nil
+}
@@ -0,0 +1,325 @@
+package whilst
+
+import (
+	"math"
+	"testing"
+	"time"
+
+	"github.com/akramarenkov/safe"
+	"github.com/stretchr/testify/require"
+)
+
+func TestAdd(t *testing.T) {
+	require.Equal(t, Whilst{Years: 1, Months: 3}, Whilst{Years: 1}.Add(Whilst{Months: 3}))
+	require.Equal(t, Whilst{Months: 9}, Whilst{Years: 1}.Add(Whilst{Months: 3, Negative: true}))
+	require.Equal(
+		t,
+		Whilst{Years: 1, Months: 9, Negative: true},
+		Whilst{Years: 2, Negative: true}.Add(Whilst{Months: 3}),
+	)
+	require.Equal(t, Whilst{}, Whilst{Days: 1}.Add(Whilst{Days: 1, Negative: true}))
+	require.Equal(t, Whilst{Weeks: 3, Days: 1}, Whilst{Weeks: 1, Days: 1}.Add(Whilst{Weeks: 2}))
+	require.Equal(
+		t,
+		Whilst{Days: 3, Nano: -time.Hour, Negative: true},
+		Whilst{Days: 1, Nano: time.Hour, Negative: true}.Add(Whilst{Days: 2, Negative: true}),
+	)
+	require.Equal(
+		t,
+		Whilst{Nano: time.Minute},
+		Whilst{Nano: time.Hour}.Add(Whilst{Nano: -59 * time.Minute}),
+	)
+	require.Equal(
+		t,
+		Whilst{Years: 65535, Months: 65535, Days: 65535, Nano: math.MaxInt64},
+		Whilst{Years: 65534, Months: 65534, Days: 65534, Nano: math.MaxInt64 - 1}.Add(
+			Whilst{Years: 1, Months: 1, Days: 1, Nano: 1},
+		),
+	)
+
+	require.Panics(t, func() { _ = Whilst{Months: 1}.Add(Whilst{Days: 1, Negative: true}) })
+	require.Equal(t, Whilst{Days: 6}, Whilst{Weeks: 1}.Add(Whilst{Days: 1, Negative: true}))
+	require.Equal(
+		t,
+		Whilst{Weeks: 1, Days: 5, Negative: true},
+		Whilst{Weeks: 2, Negative: true}.Add(Whilst{Days: 2}),
+	)
+	require.Panics(t, func() { _ = Whilst{Weeks: math.MaxUint16}.Add(Whilst{Weeks: 1}) })
+}
+
+func TestAddChecked(t *testing.T) {
+	_, err := Whilst{Months: 1}.AddChecked(Whilst{Days: 1, Negative: true})
+	require.ErrorIs(t, err, ErrMixedSigns)
+
+	_, err = Whilst{Days: 1}.AddChecked(Whilst{Nano: -time.Hour})
+	require.ErrorIs(t, err, ErrMixedSigns)
+
+	_, err = Whilst{Years: 1, Days: 1}.AddChecked(Whilst{Months: 13, Negative: true})
+	require.ErrorIs(t, err, ErrMixedSigns)
+
+	_, err = Whilst{Years: math.MaxUint16}.AddChecked(Whilst{Years: 1})
+	require.ErrorIs(t, err, safe.ErrOverflow)
+
+	_, err = Whilst{Months: math.MaxUint16}.AddChecked(Whilst{Months: 1})
+	require.ErrorIs(t, err, safe.ErrOverflow)
+
+	_, err = Whilst{Days: math.MaxUint16, Negative: true}.AddChecked(
+		Whilst{Days: 1, Negative: true},
+	)
+	require.ErrorIs(t, err, safe.ErrOverflow)
+
+	_, err = Whilst{Nano: math.MaxInt64}.AddChecked(Whilst{Nano: 1})
+	require.ErrorIs(t, err, safe.ErrOverflow)
+
+	_, err = Whilst{Nano: math.MinInt64}.AddChecked(Whilst{Nano: 1, Negative: true})
+	require.ErrorIs(t, err, safe.ErrOverflow)
+
+	sum, err := Whilst{Nano: math.MinInt64}.AddChecked(Whilst{Nano: math.MaxInt64})
+	require.NoError(t, err)
+	require.Equal(t, Whilst{Nano: -1, Negative: true}, sum)
+}
+
+func TestSub(t *testing.T) {
+	require.Equal(t, Whilst{Months: 9}, Whilst{Years: 1}.Sub(Whilst{Months: 3}))
+	require.Equal(t, Whilst{Years: 1, Months: 3}, Whilst{Years: 1}.Sub(Whilst{Months: 3, Negative: true}))
+	require.Equal(t, Whilst{Days: 1, Negative: true}, Whilst{}.Sub(Whilst{Days: 1}))
+	require.Equal(
+		t,
+		Whilst{Nano: math.MaxInt64},
+		Whilst{Nano: -1}.Sub(Whilst{Nano: math.MinInt64}),
+	)
+
+	require.Panics(t, func() { _ = Whilst{Days: 1}.Sub(Whilst{Nano: time.Hour}) })
+
+	_, err := Whilst{Nano: math.MinInt64}.SubChecked(Whilst{Nano: 1})
+	require.ErrorIs(t, err, safe.ErrOverflow)
+
+	_, err = Whilst{Years: math.MaxUint16}.SubChecked(Whilst{Years: 1, Negative: true})
+	require.ErrorIs(t, err, safe.ErrOverflow)
+}
+
+func TestNeg(t *testing.T) {
+	require.Equal(t, Whilst{}, Whilst{}.Neg())
+	require.Equal(t, Whilst{}, Whilst{Negative: true}.Neg())
+	require.Equal(t, Whilst{Years: 1, Negative: true}, Whilst{Years: 1}.Neg())
+	require.Equal(t, Whilst{Years: 1}, Whilst{Years: 1, Negative: true}.Neg())
+	require.Equal(
+		t,
+		Whilst{Days: 1, Nano: -time.Hour, Negative: true},
+		Whilst{Days: 1, Nano: time.Hour}.Neg(),
+	)
+	require.Equal(
+		t,
+		Whilst{Days: 1, Nano: time.Hour},
+		Whilst{Days: 1, Nano: time.Hour, Negative: true}.Neg(),
+	)
+	require.Equal(t, Whilst{Nano: math.MaxInt64}, Whilst{Nano: math.MinInt64}.Neg())
+
+	_, err := Whilst{Nano: math.MinInt64}.NegChecked()
+	require.ErrorIs(t, err, safe.ErrOverflow)
+}
+
+func TestAbs(t *testing.T) {
+	require.Equal(t, Whilst{}, Whilst{}.Abs())
+	require.Equal(t, Whilst{}, Whilst{Negative: true}.Abs())
+	require.Equal(t, Whilst{Years: 1}, Whilst{Years: 1}.Abs())
+	require.Equal(t, Whilst{Years: 1}, Whilst{Years: 1, Negative: true}.Abs())
+	require.Equal(t, Whilst{Nano: time.Hour}, Whilst{Nano: -time.Hour}.Abs())
+	require.Equal(t, Whilst{Nano: math.MaxInt64}, Whilst{Nano: math.MinInt64}.Abs())
+
+	abs, err := Whilst{Days: 1, Nano: time.Hour, Negative: true}.AbsChecked()
+	require.NoError(t, err)
+	require.Equal(t, Whilst{Days: 1, Nano: time.Hour}, abs)
+
+	abs, err = Whilst{Negative: true}.AbsChecked()
+	require.NoError(t, err)
+	require.Equal(t, Whilst{}, abs)
+
+	_, err = Whilst{Nano: math.MinInt64}.AbsChecked()
+	require.ErrorIs(t, err, safe.ErrOverflow)
+}
+
+func TestIsNegative(t *testing.T) {
+	require.False(t, Whilst{}.IsNegative())
+	require.False(t, Whilst{Negative: true}.IsNegative())
+	require.False(t, Whilst{Days: 1}.IsNegative())
+	require.True(t, Whilst{Days: 1, Negative: true}.IsNegative())
+	require.True(t, Whilst{Nano: -1}.IsNegative())
+	require.True(t, Whilst{Nano: 1, Negative: true}.IsNegative())
+}
+
+func FuzzAddSub(f *testing.F) {
+	f.Add(int64(math.MaxInt64), uint16(1), uint16(11), uint16(2), false, int64(1), uint16(0), uint16(1), uint16(1), true)
+	f.Add(int64(math.MinInt64), uint16(0), uint16(0), uint16(3), false, int64(-1), uint16(0), uint16(5), uint16(1), false)
+
+	f.Fuzz(
+		func(
+			t *testing.T,
+			nano1 int64, days1, months1, years1 uint16, negative1 bool,
+			nano2 int64, days2, months2, years2 uint16, negative2 bool,
+		) {
+			first := Whilst{
+				Nano:     time.Duration(nano1),
+				Days:     days1,
+				Months:   months1,
+				Years:    years1,
+				Negative: negative1,
+			}
+
+			second := Whilst{
+				Nano:     time.Duration(nano2),
+				Days:     days2,
+				Months:   months2,
+				Years:    years2,
+				Negative: negative2,
+			}
+
+			sum, err := first.AddChecked(second)
+			if err != nil {
+				return
+			}
+
+			diff, err := sum.SubChecked(second)
+			require.NoError(t, err)
+			require.Equal(t, first.signed().total(), diff.signed().total())
+
+			from := time.Date(2025, time.January, 15, 12, 0, 0, 0, time.UTC)
+
+			if sum.Years|sum.Months == 0 && first.Years|first.Months == 0 &&
+				second.Years|second.Months == 0 {
+				require.Equal(t, first.When(from).Add(second.Duration(from)), sum.When(from))
+			}
+		},
+	)
+}
+
+func TestMul(t *testing.T) {
+	require.Equal(t, Whilst{Months: 3}, Whilst{Months: 1}.Mul(3))
+	require.Equal(t, Whilst{Days: 168}, Whilst{Days: 14}.Mul(12))
+	require.Equal(t, Whilst{Weeks: 6, Days: 3, Negative: true}, Whilst{Weeks: 2, Days: 1}.Mul(-3))
+	require.Equal(t, Whilst{}, Whilst{Days: 14}.Mul(0))
+	require.Equal(t, Whilst{}, Whilst{Negative: true}.Mul(2))
+	require.Equal(
+		t,
+		Whilst{Years: 2, Months: 4, Days: 6, Nano: -2 * time.Hour, Negative: true},
+		Whilst{Years: 1, Months: 2, Days: 3, Nano: time.Hour}.Mul(-2),
+	)
+	require.Equal(
+		t,
+		Whilst{Years: 2, Months: 4, Days: 6, Nano: 2 * time.Hour},
+		Whilst{Years: 1, Months: 2, Days: 3, Nano: time.Hour, Negative: true}.Mul(-2),
+	)
+	require.Equal(
+		t,
+		Whilst{Years: 65535, Nano: math.MinInt64 + 1, Negative: true},
+		Whilst{Years: 65535, Nano: math.MaxInt64}.Mul(-1),
+	)
+
+	require.Panics(t, func() { _ = Whilst{Years: 32768}.Mul(2) })
+}
+
+func TestMulChecked(t *testing.T) {
+	product, err := Whilst{Years: 21845}.MulChecked(3)
+	require.NoError(t, err)
+	require.Equal(t, Whilst{Years: 65535}, product)
+
+	_, err = Whilst{Years: 21846}.MulChecked(3)
+	require.ErrorIs(t, err, safe.ErrOverflow)
+
+	_, err = Whilst{Months: 2}.MulChecked(math.MaxUint16)
+	require.ErrorIs(t, err, safe.ErrOverflow)
+
+	_, err = Whilst{Days: 1}.MulChecked(math.MinInt)
+	require.ErrorIs(t, err, safe.ErrOverflow)
+
+	_, err = Whilst{Nano: math.MinInt64}.MulChecked(-1)
+	require.ErrorIs(t, err, safe.ErrOverflow)
+
+	_, err = Whilst{Nano: math.MaxInt64/2 + 1}.MulChecked(2)
+	require.ErrorIs(t, err, safe.ErrOverflow)
+}
+
+func TestDiv(t *testing.T) {
+	expected := []struct {
+		Dividend Whilst
+		Divisor  int
+		Quotient Whilst
+	}{
+		{Whilst{Years: 1}, 4, Whilst{Months: 3}},
+		{Whilst{Years: 1}, 3, Whilst{Months: 4}},
+		{Whilst{Years: 2}, 2, Whilst{Years: 1}},
+		{Whilst{Years: 3}, 2, Whilst{Years: 1, Months: 6}},
+		{Whilst{Years: 1, Months: 6}, 3, Whilst{Months: 6}},
+		{Whilst{Days: 14}, 7, Whilst{Days: 2}},
+		{Whilst{Weeks: 1}, 7, Whilst{Days: 1}},
+		{Whilst{Weeks: 3, Days: 1}, 2, Whilst{Weeks: 1, Days: 4}},
+		{Whilst{Days: 14, Nano: time.Hour}, -2, Whilst{Days: 7, Nano: -30 * time.Minute, Negative: true}},
+		{Whilst{Months: 4, Negative: true}, -4, Whilst{Months: 1}},
+		{Whilst{Nano: math.MinInt64}, 2, Whilst{Nano: math.MinInt64 / 2, Negative: true}},
+		{Whilst{Years: 65535, Months: 65535, Days: 65535}, 1, Whilst{Years: 65535, Months: 65535, Days: 65535}},
+		{Whilst{Years: 65535, Months: 65535}, 65535, Whilst{Years: 1, Months: 1}},
+		{Whilst{}, 5, Whilst{}},
+		{Whilst{Negative: true}, 5, Whilst{}},
+	}
+
+	for _, item := range expected {
+		quotient, err := item.Dividend.Div(item.Divisor)
+		require.NoError(t, err, "dividend: %v, divisor: %v", item.Dividend, item.Divisor)
+		require.Equal(t, item.Quotient, quotient, "dividend: %v, divisor: %v", item.Dividend, item.Divisor)
+	}
+}
+
+func TestDivError(t *testing.T) {
+	expected := []struct {
+		Dividend Whilst
+		Divisor  int
+		Err      error
+	}{
+		{Whilst{Years: 1}, 5, ErrInexactDivision},
+		{Whilst{Months: 1}, 2, ErrInexactDivision},
+		{Whilst{Years: 1, Months: 1}, 2, ErrInexactDivision},
+		{Whilst{Days: 1}, 2, ErrInexactDivision},
+		{Whilst{Nano: 1}, 2, ErrInexactDivision},
+		{Whilst{Days: 2, Nano: 1}, 2, ErrInexactDivision},
+		{Whilst{Years: 1}, 0, safe.ErrDivisionByZero},
+		{Whilst{}, 0, safe.ErrDivisionByZero},
+		{Whilst{Nano: math.MinInt64}, -1, safe.ErrOverflow},
+	}
+
+	for _, item := range expected {
+		quotient, err := item.Dividend.Div(item.Divisor)
+		require.ErrorIs(t, err, item.Err, "dividend: %v, divisor: %v", item.Dividend, item.Divisor)
+		require.Equal(t, Whilst{}, quotient, "dividend: %v, divisor: %v", item.Dividend, item.Divisor)
+	}
+}
+
+func FuzzMulDiv(f *testing.F) {
+	f.Add(int64(math.MaxInt64), uint16(1), uint16(11), uint16(2), false, 3)
+	f.Add(int64(math.MinInt64), uint16(0), uint16(0), uint16(3), true, -1)
+
+	f.Fuzz(
+		func(t *testing.T, nano int64, days, months, years uint16, negative bool, factor int) {
+			origin := Whilst{
+				Nano:     time.Duration(nano),
+				Days:     days,
+				Months:   months,
+				Years:    years,
+				Negative: negative,
+			}
+
+			product, err := origin.MulChecked(factor)
+			if err != nil || factor == 0 {
+				return
+			}
+
+			quotient, err := product.Div(factor)
+			require.NoError(t, err)
+			require.Equal(t, origin.signed().total(), quotient.signed().total())
+		},
+	)
+}
+
+// Returns total number of months, days and nanoseconds.
+func (sgn signed) total() [3]int64 {
+	return [3]int64{sgn.years*monthsInYear + sgn.months, sgn.days, sgn.nano}
+}
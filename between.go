@@ -0,0 +1,108 @@
+package whilst
+
+import (
+	"time"
+
+	"github.com/akramarenkov/intspec"
+	"github.com/akramarenkov/safe"
+)
+
+// Returns the duration between two times, i.e. such duration that its When(from)
+// is equal to the time to.
+//
+// Panics if the duration cannot be represented, see [BetweenChecked].
+func Between(from, to time.Time) Whilst {
+	whl, err := BetweenChecked(from, to)
+	if err != nil {
+		panic(err)
+	}
+
+	return whl
+}
+
+// Returns the duration between two times, i.e. such duration that its When(from)
+// is equal to the time to.
+//
+// The duration is decomposed into the largest possible units: first the maximum
+// number of months (and years) is selected, then the maximum number of days and
+// the remainder is represented by nanoseconds. Thus, the month-end behavior of
+// [Whilst.When] is taken into account, e.g. between January 31 and March 3 of
+// a non-leap year is 1mo, and between January 31 and February 28 is 28d.
+//
+// If the time to is before the time from, then the duration is negative. Days are
+// calculated in the location of the time from. If the number of years exceeds
+// 65535, then [safe.ErrOverflow] is returned.
+func BetweenChecked(from, to time.Time) (Whilst, error) {
+	to = to.In(from.Location())
+
+	negative := to.Before(from)
+
+	months := (to.Year()-from.Year())*monthsInYear + int(to.Month()-from.Month())
+
+	// Estimation of months is exceeded by no more than one month for the
+	// negative duration and is never insufficient for the positive one, taking
+	// into account the normalization performed by time.AddDate
+	if negative {
+		months--
+
+		for from.AddDate(0, months, 0).Before(to) {
+			months++
+		}
+	} else {
+		for from.AddDate(0, months, 0).After(to) {
+			months--
+		}
+	}
+
+	days := daysBetween(from.AddDate(0, months, 0), to)
+
+	// Candidates are calculated by a single call of time.AddDate, just like
+	// Whilst.When does, since the time that falls into a gap of a daylight saving time
+	// transition is normalized differently by successive calls
+	if negative {
+		for from.AddDate(0, months, days).Before(to) {
+			days++
+		}
+
+		for !from.AddDate(0, months, days-1).Before(to) {
+			days--
+		}
+	} else {
+		for from.AddDate(0, months, days).After(to) {
+			days--
+		}
+
+		for !from.AddDate(0, months, days+1).After(to) {
+			days++
+		}
+	}
+
+	nano := to.Sub(from.AddDate(0, months, days))
+
+	years := safe.Abs(months) / monthsInYear
+
+	if years > intspec.MaxUint16 {
+		return Whilst{}, safe.ErrOverflow
+	}
+
+	whl := Whilst{
+		Nano:     nano,
+		Days:     uint16(safe.Abs(days)),
+		Months:   uint16(safe.Abs(months) % monthsInYear),
+		Years:    uint16(years),
+		Negative: negative,
+	}
+
+	return whl, nil
+}
+
+// Returns the number of calendar days between dates of two times without taking
+// into account time of day.
+func daysBetween(from, to time.Time) int {
+	const day = 24 * time.Hour
+
+	fromDate := time.Date(from.Year(), from.Month(), from.Day(), 0, 0, 0, 0, time.UTC)
+	toDate := time.Date(to.Year(), to.Month(), to.Day(), 0, 0, 0, 0, time.UTC)
+
+	return int(toDate.Sub(fromDate) / day)
+}
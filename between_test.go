@@ -0,0 +1,161 @@
+package whilst
+
+import (
+	"math"
+	"testing"
+	"time"
+	_ "time/tzdata"
+
+	"github.com/akramarenkov/safe"
+	"github.com/stretchr/testify/require"
+)
+
+func TestBetween(t *testing.T) {
+	expected := []struct {
+		From   time.Time
+		To     time.Time
+		Whilst Whilst
+	}{
+		{
+			From:   time.Date(2023, time.April, 1, 0, 0, 0, 0, time.UTC),
+			To:     time.Date(2025, time.July, 11, 0, 30, 0, 0, time.UTC),
+			Whilst: Whilst{Years: 2, Months: 3, Days: 10, Nano: 30 * time.Minute},
+		},
+		{
+			From:   time.Date(2025, time.July, 11, 0, 30, 0, 0, time.UTC),
+			To:     time.Date(2023, time.April, 1, 0, 0, 0, 0, time.UTC),
+			Whilst: Whilst{Years: 2, Months: 3, Days: 10, Nano: -30 * time.Minute, Negative: true},
+		},
+		{
+			From:   time.Date(2023, time.January, 31, 0, 0, 0, 0, time.UTC),
+			To:     time.Date(2023, time.March, 3, 0, 0, 0, 0, time.UTC),
+			Whilst: Whilst{Months: 1},
+		},
+		{
+			From:   time.Date(2023, time.January, 31, 0, 0, 0, 0, time.UTC),
+			To:     time.Date(2023, time.February, 28, 0, 0, 0, 0, time.UTC),
+			Whilst: Whilst{Days: 28},
+		},
+		{
+			From:   time.Date(2023, time.March, 31, 0, 0, 0, 0, time.UTC),
+			To:     time.Date(2023, time.February, 28, 0, 0, 0, 0, time.UTC),
+			Whilst: Whilst{Months: 1, Days: 3, Negative: true},
+		},
+		{
+			From:   time.Date(2023, time.March, 10, 12, 0, 0, 0, time.UTC),
+			To:     time.Date(2023, time.January, 20, 6, 0, 0, 0, time.UTC),
+			Whilst: Whilst{Months: 1, Days: 21, Nano: -6 * time.Hour, Negative: true},
+		},
+		{
+			From:   time.Date(2000, time.February, 29, 0, 0, 0, 0, time.UTC),
+			To:     time.Date(2001, time.February, 28, 0, 0, 0, 0, time.UTC),
+			Whilst: Whilst{Months: 11, Days: 30},
+		},
+		{
+			From:   time.Date(2023, time.January, 1, 23, 0, 0, 0, time.UTC),
+			To:     time.Date(2023, time.January, 2, 1, 0, 0, 0, time.UTC),
+			Whilst: Whilst{Nano: 2 * time.Hour},
+		},
+		{
+			From:   time.Date(2023, time.January, 1, 0, 0, 0, 0, time.UTC),
+			To:     time.Date(2023, time.January, 1, 0, 0, 0, 0, time.FixedZone("", 3600)),
+			Whilst: Whilst{Nano: -time.Hour, Negative: true},
+		},
+		{
+			From:   time.Date(2023, time.January, 1, 0, 0, 0, 0, time.UTC),
+			To:     time.Date(2023, time.January, 1, 0, 0, 0, 0, time.UTC),
+			Whilst: Whilst{},
+		},
+	}
+
+	for _, item := range expected {
+		whl := Between(item.From, item.To)
+		require.Equal(t, item.Whilst, whl, "from: %v, to: %v", item.From, item.To)
+		require.True(t, whl.When(item.From).Equal(item.To), "from: %v, to: %v", item.From, item.To)
+	}
+}
+
+func TestBetweenDST(t *testing.T) {
+	location, err := time.LoadLocation("America/New_York")
+	require.NoError(t, err)
+
+	// Day of transition to daylight saving time lasts 23 hours
+	from := time.Date(2023, time.March, 12, 0, 0, 0, 0, location)
+	to := time.Date(2023, time.March, 13, 0, 0, 0, 0, location)
+
+	whl := Between(from, to)
+	require.Equal(t, Whilst{Days: 1}, whl)
+	require.Equal(t, 23*time.Hour, whl.Duration(from))
+
+	from = time.Date(2023, time.March, 12, 1, 30, 0, 0, location)
+	to = time.Date(2023, time.March, 12, 3, 30, 0, 0, location)
+
+	whl = Between(from, to)
+	require.Equal(t, Whilst{Nano: time.Hour}, whl)
+	require.True(t, whl.When(from).Equal(to))
+
+	location, err = time.LoadLocation("Europe/Berlin")
+	require.NoError(t, err)
+
+	// Shift by months alone falls into the gap of the transition to the summer time
+	// on March 31, 2024, whereas shift by months and days does not
+	from = time.Date(2024, time.January, 31, 2, 30, 0, 0, location)
+	to = time.Date(2024, time.April, 5, 12, 0, 0, 0, location)
+
+	whl = Between(from, to)
+	require.Equal(t, Whilst{Months: 2, Days: 5, Nano: 9*time.Hour + 30*time.Minute}, whl)
+	require.True(t, whl.When(from).Equal(to))
+}
+
+func TestBetweenOverflow(t *testing.T) {
+	from := time.Date(0, time.January, 1, 0, 0, 0, 0, time.UTC)
+	to := time.Date(65536, time.January, 1, 0, 0, 0, 0, time.UTC)
+
+	_, err := BetweenChecked(from, to)
+	require.ErrorIs(t, err, safe.ErrOverflow)
+
+	_, err = BetweenChecked(to, from)
+	require.ErrorIs(t, err, safe.ErrOverflow)
+
+	require.Panics(t, func() { _ = Between(from, to) })
+
+	whl, err := BetweenChecked(from, to.Add(-time.Nanosecond))
+	require.NoError(t, err)
+	require.Equal(
+		t,
+		Whilst{Years: 65535, Months: 11, Days: 30, Nano: 24*time.Hour - 1},
+		whl,
+	)
+}
+
+func FuzzBetween(f *testing.F) {
+	f.Add(int64(0), int64(0), false)
+	f.Add(int64(math.MaxInt32), int64(math.MinInt32), true)
+	f.Add(int64(1675123200), int64(1677801600), true)
+	// From 2024-01-31 02:30 to 2024-04-05 12:00 in Europe/Berlin, shift by months
+	// alone falls into the gap of the transition to the summer time
+	f.Add(int64(1706664600), int64(1712311200), true)
+
+	location, err := time.LoadLocation("Europe/Berlin")
+	require.NoError(f, err)
+
+	f.Fuzz(
+		func(t *testing.T, fromUnix, toUnix int64, local bool) {
+			const limit = 1 << 40
+
+			if fromUnix > limit || fromUnix < -limit || toUnix > limit || toUnix < -limit {
+				return
+			}
+
+			from := time.Unix(fromUnix, 0).UTC()
+			to := time.Unix(toUnix, 0).UTC()
+
+			if local {
+				from = from.In(location)
+			}
+
+			whl := Between(from, to)
+			require.True(t, whl.When(from).Equal(to), "from: %v, to: %v, whilst: %v", from, to, whl)
+		},
+	)
+}
@@ -0,0 +1,91 @@
+package whilst
+
+import (
+	"math"
+	"math/big"
+	"time"
+)
+
+// Returns the minimum of the durations, corresponding to the duration, relative to
+// all possible times from in UTC, i.e. the minimum of [Whilst.Duration].
+//
+// Months are considered to be from 28 to 31 days and years to be 365 or 366 days
+// according to the Gregorian calendar. If the result cannot be represented by
+// time.Duration, then it is saturated to the minimum or maximum value.
+func (whl Whilst) MinDuration() time.Duration {
+	lower, _ := whl.bounds(false)
+	return lower
+}
+
+// Returns the maximum of the durations, corresponding to the duration, relative to
+// all possible times from in UTC, i.e. the maximum of [Whilst.Duration].
+//
+// Months are considered to be from 28 to 31 days and years to be 365 or 366 days
+// according to the Gregorian calendar. If the result cannot be represented by
+// time.Duration, then it is saturated to the minimum or maximum value.
+func (whl Whilst) MaxDuration() time.Duration {
+	_, upper := whl.bounds(false)
+	return upper
+}
+
+// Returns the minimum of the durations, corresponding to the duration, relative to
+// all possible times from in the specified location.
+//
+// Unlike [Whilst.MinDuration], if the location is specified and is not UTC, it is
+// considered that a shift by years, months, weeks and days can be shortened by
+// an hour due to daylight saving time transitions. Thus, the result is conservative
+// for locations with a fixed offset.
+func (whl Whilst) MinDurationIn(location *time.Location) time.Duration {
+	lower, _ := whl.bounds(isVariable(location))
+	return lower
+}
+
+// Returns the maximum of the durations, corresponding to the duration, relative to
+// all possible times from in the specified location.
+//
+// Unlike [Whilst.MaxDuration], if the location is specified and is not UTC, it is
+// considered that a shift by years, months, weeks and days can be lengthened by
+// an hour due to daylight saving time transitions. Thus, the result is conservative
+// for locations with a fixed offset.
+func (whl Whilst) MaxDurationIn(location *time.Location) time.Duration {
+	_, upper := whl.bounds(isVariable(location))
+	return upper
+}
+
+func isVariable(location *time.Location) bool {
+	return location != nil && location != time.UTC
+}
+
+func (whl Whilst) bounds(variable bool) (time.Duration, time.Duration) {
+	sgn := whl.signed()
+
+	shortest, longest := monthsShift(sgn.years*monthsInYear + sgn.months)
+	days, nano := sgn.fixedPart()
+
+	shift := int64(0)
+
+	if variable && sgn.years|sgn.months|sgn.weeks|sgn.days != 0 {
+		shift = int64(time.Hour)
+	}
+
+	lower := toDuration(shortest+days, nano-shift)
+	upper := toDuration(longest+days, nano+shift)
+
+	return lower, upper
+}
+
+// Converts days and nanoseconds to time.Duration with saturation.
+func toDuration(days int64, nano int64) time.Duration {
+	total := new(big.Int).Mul(big.NewInt(days), big.NewInt(nanoInDay))
+	total.Add(total, big.NewInt(nano))
+
+	if total.IsInt64() {
+		return time.Duration(total.Int64())
+	}
+
+	if total.Sign() < 0 {
+		return math.MinInt64
+	}
+
+	return math.MaxInt64
+}
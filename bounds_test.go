@@ -0,0 +1,160 @@
+package whilst
+
+import (
+	"math"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestBounds(t *testing.T) {
+	const day = 24 * time.Hour
+
+	expected := []struct {
+		Whilst Whilst
+		Min    time.Duration
+		Max    time.Duration
+	}{
+		{
+			Whilst: Whilst{},
+			Min:    0,
+			Max:    0,
+		},
+		{
+			Whilst: Whilst{Nano: -time.Hour},
+			Min:    -time.Hour,
+			Max:    -time.Hour,
+		},
+		{
+			Whilst: Whilst{Months: 1, Days: 3},
+			Min:    31 * day,
+			Max:    34 * day,
+		},
+		{
+			Whilst: Whilst{Months: 1, Days: 3, Negative: true},
+			Min:    -34 * day,
+			Max:    -31 * day,
+		},
+		{
+			Whilst: Whilst{Years: 1, Weeks: 1, Nano: time.Hour},
+			Min:    372*day + time.Hour,
+			Max:    373*day + time.Hour,
+		},
+		{
+			Whilst: Whilst{Months: 2},
+			Min:    59 * day,
+			Max:    62 * day,
+		},
+		{
+			Whilst: Whilst{Years: 4},
+			Min:    1460 * day,
+			Max:    1461 * day,
+		},
+		{
+			Whilst: Whilst{Years: 400},
+			Min:    math.MaxInt64,
+			Max:    math.MaxInt64,
+		},
+		{
+			Whilst: Whilst{Years: 292},
+			Min:    106650 * day,
+			Max:    106652 * day,
+		},
+		{
+			Whilst: Whilst{Years: 293},
+			Min:    math.MaxInt64,
+			Max:    math.MaxInt64,
+		},
+		{
+			Whilst: Whilst{Years: 400, Negative: true},
+			Min:    math.MinInt64,
+			Max:    math.MinInt64,
+		},
+		{
+			Whilst: Whilst{Nano: math.MinInt64},
+			Min:    math.MinInt64,
+			Max:    math.MinInt64,
+		},
+	}
+
+	for _, item := range expected {
+		require.Equal(t, item.Min, item.Whilst.MinDuration(), "whilst: %v", item.Whilst)
+		require.Equal(t, item.Max, item.Whilst.MaxDuration(), "whilst: %v", item.Whilst)
+		require.Equal(t, item.Min, item.Whilst.MinDurationIn(nil), "whilst: %v", item.Whilst)
+		require.Equal(t, item.Max, item.Whilst.MaxDurationIn(time.UTC), "whilst: %v", item.Whilst)
+	}
+}
+
+func TestBoundsIn(t *testing.T) {
+	location, err := time.LoadLocation("Europe/Berlin")
+	require.NoError(t, err)
+
+	whl := Whilst{Months: 1, Days: 3}
+
+	require.Equal(t, 31*24*time.Hour-time.Hour, whl.MinDurationIn(location))
+	require.Equal(t, 34*24*time.Hour+time.Hour, whl.MaxDurationIn(location))
+
+	whl = Whilst{Nano: time.Hour}
+
+	require.Equal(t, time.Hour, whl.MinDurationIn(location))
+	require.Equal(t, time.Hour, whl.MaxDurationIn(location))
+
+	whl = Whilst{Nano: math.MinInt64, Days: 1}
+
+	require.Equal(t, time.Duration(math.MinInt64), whl.MinDurationIn(location))
+	require.Equal(t, time.Duration(math.MinInt64), whl.MaxDurationIn(location))
+
+	whl = Whilst{Nano: math.MinInt64 + 25*time.Hour, Days: 1}
+
+	require.Equal(t, time.Duration(math.MinInt64), whl.MinDurationIn(location))
+	require.Equal(t, time.Duration(math.MinInt64)+2*time.Hour, whl.MaxDurationIn(location))
+
+	// Transition to the summer time occurs on March 26, 2023
+	from := time.Date(2023, time.March, 1, 12, 0, 0, 0, location)
+
+	require.Equal(t, 34*24*time.Hour-time.Hour, Whilst{Months: 1, Days: 3}.Duration(from))
+}
+
+func FuzzBounds(f *testing.F) {
+	f.Add(uint16(0), uint16(1), uint16(0), uint16(3), int64(0), false, int64(0))
+	f.Add(uint16(1), uint16(0), uint16(1), uint16(0), int64(time.Hour), true, int64(1e17))
+	f.Add(uint16(0), uint16(13), uint16(0), uint16(0), int64(-1), false, int64(-1e17))
+
+	location, err := time.LoadLocation("America/New_York")
+	require.NoError(f, err)
+
+	f.Fuzz(
+		func(
+			t *testing.T,
+			years uint16,
+			months uint16,
+			weeks uint16,
+			days uint16,
+			nano int64,
+			negative bool,
+			anchor int64,
+		) {
+			whl := Whilst{
+				Years:    years,
+				Months:   months,
+				Weeks:    weeks,
+				Days:     days,
+				Nano:     time.Duration(nano),
+				Negative: negative,
+			}
+
+			from := time.Unix(0, anchor)
+
+			duration := whl.Duration(from.UTC())
+
+			require.LessOrEqual(t, whl.MinDuration(), duration)
+			require.GreaterOrEqual(t, whl.MaxDuration(), duration)
+
+			duration = whl.Duration(from.In(location))
+
+			require.LessOrEqual(t, whl.MinDurationIn(location), duration)
+			require.GreaterOrEqual(t, whl.MaxDurationIn(location), duration)
+		},
+	)
+}
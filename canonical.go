@@ -0,0 +1,77 @@
+package whilst
+
+import (
+	"time"
+
+	"github.com/akramarenkov/intspec"
+	"github.com/akramarenkov/safe"
+)
+
+// Options of bringing the duration to the canonical form.
+//
+// Zero value of the options corresponds to [Whilst.Canonical].
+type CanonicalOptions struct {
+	// Whether a day is considered to be exactly 24 hours, which is true only in
+	// a context without daylight saving time transitions, e.g. in UTC. If so, every 24
+	// hours of nanoseconds are folded into days
+	FixedDay bool
+}
+
+// Returns the duration in the canonical form.
+//
+// Every 12 months are folded into years, which is always exact, since a year
+// consists of 12 months regardless of the time relative to which the shift occurs.
+// Months are kept as is if years would overflow. Similarly, every week is folded
+// into 7 days, as far as days do not overflow, the rest of weeks are kept as is.
+// The sign of the zero duration is removed and the sign of a negative duration is
+// specified in both the Negative field and nanoseconds.
+//
+// Thus, durations that are equal in effect, e.g. 18mo and 1y6mo or 1w and 7d, have
+// the same canonical form and can be compared using the == operator.
+func (whl Whilst) Canonical() Whilst {
+	if whl.IsZero() {
+		return Whilst{}
+	}
+
+	whl = whl.normalize()
+
+	folded := min(uint64(whl.Months)/monthsInYear, intspec.MaxUint16-uint64(whl.Years))
+
+	whl.Years += uint16(folded)
+	whl.Months -= uint16(folded * monthsInYear)
+
+	folded = min(uint64(whl.Weeks), (intspec.MaxUint16-uint64(whl.Days))/daysInWeek)
+
+	whl.Days += uint16(folded * daysInWeek)
+	whl.Weeks -= uint16(folded)
+
+	return whl
+}
+
+// Returns the duration in the canonical form taking into account the options.
+//
+// In addition to folds of [Whilst.Canonical], if a day is considered to be fixed,
+// every 24 hours of nanoseconds are folded into days, as far as days do not
+// overflow. This fold is not exact in general case, e.g. 1d and 24h differ by an hour
+// when shifting over a daylight saving time transition.
+func (whl Whilst) CanonicalWith(opts CanonicalOptions) Whilst {
+	whl = whl.Canonical()
+
+	if !opts.FixedDay {
+		return whl
+	}
+
+	const day = 24 * time.Hour
+
+	folded := min(safe.Abs(whl.Nano)/uint64(day), intspec.MaxUint16-uint64(whl.Days))
+
+	whl.Days += uint16(folded)
+
+	if whl.Nano < 0 {
+		whl.Nano += time.Duration(folded) * day
+	} else {
+		whl.Nano -= time.Duration(folded) * day
+	}
+
+	return whl
+}
@@ -0,0 +1,158 @@
+package whilst
+
+import (
+	"math"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestCanonical(t *testing.T) {
+	expected := []struct {
+		Whilst   Whilst
+		Expected Whilst
+	}{
+		{
+			Whilst:   Whilst{},
+			Expected: Whilst{},
+		},
+		{
+			Whilst:   Whilst{Negative: true},
+			Expected: Whilst{},
+		},
+		{
+			Whilst:   Whilst{Months: 18},
+			Expected: Whilst{Years: 1, Months: 6},
+		},
+		{
+			Whilst:   Whilst{Years: 1, Months: 6},
+			Expected: Whilst{Years: 1, Months: 6},
+		},
+		{
+			Whilst:   Whilst{Months: 24, Weeks: 2, Days: 14, Nano: -48 * time.Hour},
+			Expected: Whilst{Years: 2, Days: 28, Nano: -48 * time.Hour, Negative: true},
+		},
+		{
+			Whilst:   Whilst{Weeks: 1},
+			Expected: Whilst{Days: 7},
+		},
+		{
+			Whilst:   Whilst{Weeks: 1, Days: 3, Negative: true},
+			Expected: Whilst{Days: 10, Negative: true},
+		},
+		{
+			Whilst:   Whilst{Weeks: 3, Days: math.MaxUint16 - 15},
+			Expected: Whilst{Weeks: 1, Days: math.MaxUint16 - 1},
+		},
+		{
+			Whilst:   Whilst{Weeks: math.MaxUint16, Days: math.MaxUint16},
+			Expected: Whilst{Weeks: math.MaxUint16, Days: math.MaxUint16},
+		},
+		{
+			Whilst:   Whilst{Months: 12, Nano: time.Hour, Negative: true},
+			Expected: Whilst{Years: 1, Nano: -time.Hour, Negative: true},
+		},
+		{
+			Whilst:   Whilst{Years: math.MaxUint16 - 1, Months: 36},
+			Expected: Whilst{Years: math.MaxUint16, Months: 24},
+		},
+		{
+			Whilst:   Whilst{Years: math.MaxUint16, Months: math.MaxUint16},
+			Expected: Whilst{Years: math.MaxUint16, Months: math.MaxUint16},
+		},
+	}
+
+	for _, item := range expected {
+		require.Equal(t, item.Expected, item.Whilst.Canonical(), "whilst: %+v", item.Whilst)
+		require.Equal(
+			t,
+			item.Expected,
+			item.Whilst.CanonicalWith(CanonicalOptions{}),
+			"whilst: %+v",
+			item.Whilst,
+		)
+	}
+}
+
+func TestCanonicalFixedDay(t *testing.T) {
+	opts := CanonicalOptions{FixedDay: true}
+
+	expected := []struct {
+		Whilst   Whilst
+		Expected Whilst
+	}{
+		{
+			Whilst:   Whilst{Nano: 23 * time.Hour},
+			Expected: Whilst{Nano: 23 * time.Hour},
+		},
+		{
+			Whilst:   Whilst{Months: 13, Days: 1, Nano: 49 * time.Hour},
+			Expected: Whilst{Years: 1, Months: 1, Days: 3, Nano: time.Hour},
+		},
+		{
+			Whilst:   Whilst{Nano: -48 * time.Hour},
+			Expected: Whilst{Days: 2, Negative: true},
+		},
+		{
+			Whilst:   Whilst{Nano: -25 * time.Hour, Negative: true},
+			Expected: Whilst{Days: 1, Nano: -time.Hour, Negative: true},
+		},
+		{
+			Whilst:   Whilst{Days: math.MaxUint16 - 1, Nano: 72 * time.Hour},
+			Expected: Whilst{Days: math.MaxUint16, Nano: 48 * time.Hour},
+		},
+		{
+			Whilst: Whilst{Nano: math.MinInt64},
+			Expected: Whilst{
+				Days:     math.MaxUint16,
+				Nano:     math.MinInt64 + math.MaxUint16*24*time.Hour,
+				Negative: true,
+			},
+		},
+	}
+
+	for _, item := range expected {
+		require.Equal(t, item.Expected, item.Whilst.CanonicalWith(opts), "whilst: %+v", item.Whilst)
+	}
+}
+
+func FuzzCanonical(f *testing.F) {
+	f.Add(uint16(0), uint16(18), uint16(1), uint16(1), int64(49*time.Hour), false)
+	f.Add(uint16(65535), uint16(65535), uint16(0), uint16(65535), int64(math.MinInt64), true)
+
+	from := time.Date(2023, time.January, 31, 12, 0, 0, 0, time.UTC)
+
+	f.Fuzz(
+		func(
+			t *testing.T,
+			years uint16,
+			months uint16,
+			weeks uint16,
+			days uint16,
+			nano int64,
+			negative bool,
+		) {
+			whl := Whilst{
+				Years:    years,
+				Months:   months,
+				Weeks:    weeks,
+				Days:     days,
+				Nano:     time.Duration(nano),
+				Negative: negative,
+			}
+
+			canonical := whl.Canonical()
+
+			require.Equal(t, canonical, canonical.Canonical())
+			require.Equal(t, whl.IsNegative(), canonical.IsNegative())
+			require.Equal(t, whl.When(from), canonical.When(from))
+
+			fixed := whl.CanonicalWith(CanonicalOptions{FixedDay: true})
+
+			require.Equal(t, fixed, fixed.CanonicalWith(CanonicalOptions{FixedDay: true}))
+			require.Equal(t, whl.IsNegative(), fixed.IsNegative())
+			require.Equal(t, whl.When(from), fixed.When(from))
+		},
+	)
+}
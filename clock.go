@@ -0,0 +1,253 @@
+package whilst
+
+import (
+	"math"
+	"sync"
+	"time"
+)
+
+// Source of the current time and timers.
+type Clock interface {
+	// Returns the current time
+	Now() time.Time
+	// Returns a channel on which the current time is sent after at least
+	// the specified duration
+	After(duration time.Duration) <-chan time.Time
+	// Creates a timer that sends the current time on its channel after at least
+	// the specified duration
+	NewTimer(duration time.Duration) ClockTimer
+}
+
+// Timer created by the [Clock].
+type ClockTimer interface {
+	// Returns the channel on which the current time is sent when the timer fires
+	C() <-chan time.Time
+	// Prevents the timer from firing, reports whether the call stops the timer
+	Stop() bool
+	// Changes the timer to fire after the specified duration, reports whether
+	// the timer had been active
+	Reset(duration time.Duration) bool
+}
+
+// Returns the clock that uses the system time and timers of the time package.
+func RealClock() Clock {
+	return realClock{}
+}
+
+type realClock struct{}
+
+func (realClock) Now() time.Time {
+	return time.Now()
+}
+
+func (realClock) After(duration time.Duration) <-chan time.Time {
+	return time.After(duration)
+}
+
+func (realClock) NewTimer(duration time.Duration) ClockTimer {
+	return realTimer{timer: time.NewTimer(duration)}
+}
+
+type realTimer struct {
+	timer *time.Timer
+}
+
+func (tmr realTimer) C() <-chan time.Time {
+	return tmr.timer.C
+}
+
+func (tmr realTimer) Stop() bool {
+	return tmr.timer.Stop()
+}
+
+func (tmr realTimer) Reset(duration time.Duration) bool {
+	return tmr.timer.Reset(duration)
+}
+
+// Clock whose time changes only by calls of [FakeClock.Advance] and [FakeClock.Set],
+// intended for tests. Timers fire as soon as the current time of the clock reaches
+// their time.
+//
+// It is safe for concurrent use.
+type FakeClock struct {
+	mutex  sync.Mutex
+	now    time.Time
+	timers []*fakeTimer
+}
+
+// Creates a new fake clock with the specified current time.
+func NewFakeClock(now time.Time) *FakeClock {
+	return &FakeClock{now: now}
+}
+
+// Returns the current time of the clock.
+func (clk *FakeClock) Now() time.Time {
+	clk.mutex.Lock()
+	defer clk.mutex.Unlock()
+
+	return clk.now
+}
+
+// Returns a channel on which the current time is sent when the clock is advanced
+// by at least the specified duration.
+func (clk *FakeClock) After(duration time.Duration) <-chan time.Time {
+	return clk.NewTimer(duration).C()
+}
+
+// Creates a timer that fires when the clock is advanced by at least the specified
+// duration. If the duration is zero or negative, the timer fires immediately.
+func (clk *FakeClock) NewTimer(duration time.Duration) ClockTimer {
+	clk.mutex.Lock()
+	defer clk.mutex.Unlock()
+
+	tmr := &fakeTimer{
+		clock:   clk,
+		channel: make(chan time.Time, 1),
+	}
+
+	clk.timers = append(clk.timers, tmr)
+
+	tmr.reset(duration)
+
+	return tmr
+}
+
+// Moves the current time of the clock forward by the duration and fires timers
+// whose time is reached.
+func (clk *FakeClock) Advance(duration time.Duration) {
+	clk.mutex.Lock()
+	defer clk.mutex.Unlock()
+
+	clk.set(clk.now.Add(duration))
+}
+
+// Sets the current time of the clock and fires timers whose time is reached.
+// The time can also be moved backward.
+func (clk *FakeClock) Set(now time.Time) {
+	clk.mutex.Lock()
+	defer clk.mutex.Unlock()
+
+	clk.set(now)
+}
+
+// Returns the number of timers that have not yet fired or been stopped. It can be
+// used to wait until the code under test starts waiting on the clock.
+func (clk *FakeClock) Waiters() int {
+	clk.mutex.Lock()
+	defer clk.mutex.Unlock()
+
+	return len(clk.timers)
+}
+
+func (clk *FakeClock) set(now time.Time) {
+	clk.now = now
+
+	active := clk.timers[:0]
+
+	for _, tmr := range clk.timers {
+		if tmr.deadline.After(clk.now) {
+			active = append(active, tmr)
+			continue
+		}
+
+		tmr.fire()
+	}
+
+	clear(clk.timers[len(active):])
+
+	clk.timers = active
+}
+
+// Must be called with the mutex of the clock locked.
+func (clk *FakeClock) remove(tmr *fakeTimer) bool {
+	for id, timer := range clk.timers {
+		if timer == tmr {
+			clk.timers = append(clk.timers[:id], clk.timers[id+1:]...)
+			return true
+		}
+	}
+
+	return false
+}
+
+type fakeTimer struct {
+	clock    *FakeClock
+	channel  chan time.Time
+	deadline time.Time
+}
+
+func (tmr *fakeTimer) C() <-chan time.Time {
+	return tmr.channel
+}
+
+func (tmr *fakeTimer) Stop() bool {
+	tmr.clock.mutex.Lock()
+	defer tmr.clock.mutex.Unlock()
+
+	return tmr.clock.remove(tmr)
+}
+
+func (tmr *fakeTimer) Reset(duration time.Duration) bool {
+	tmr.clock.mutex.Lock()
+	defer tmr.clock.mutex.Unlock()
+
+	active := tmr.clock.remove(tmr)
+
+	tmr.clock.timers = append(tmr.clock.timers, tmr)
+
+	tmr.reset(duration)
+
+	return active
+}
+
+// Must be called with the mutex of the clock locked and the timer added to
+// the clock.
+func (tmr *fakeTimer) reset(duration time.Duration) {
+	tmr.deadline = tmr.clock.now.Add(duration)
+
+	if duration <= 0 {
+		tmr.clock.remove(tmr)
+		tmr.fire()
+	}
+}
+
+func (tmr *fakeTimer) fire() {
+	select {
+	case tmr.channel <- tmr.clock.now:
+	default:
+	}
+}
+
+// Returns a time shifted by the duration relative to the current time of the clock.
+// If the clock is not specified, the [RealClock] is used.
+func (whl Whilst) FromNow(clock Clock) time.Time {
+	return whl.When(now(clock))
+}
+
+// Returns a time shifted back by the duration relative to the current time of
+// the clock, i.e. the time that was the duration ago. If the clock is not specified,
+// the [RealClock] is used.
+func (whl Whilst) Ago(clock Clock) time.Time {
+	sgn := whl.signed()
+
+	shifted := now(clock).AddDate(
+		-int(sgn.years),
+		-int(sgn.months),
+		-int(sgn.weeks*daysInWeek+sgn.days),
+	)
+
+	// Negation of the minimum value of nanoseconds overflows
+	if sgn.nano == math.MinInt64 {
+		return shifted.Add(math.MaxInt64).Add(1)
+	}
+
+	return shifted.Add(-time.Duration(sgn.nano))
+}
+
+func now(clock Clock) time.Time {
+	if clock == nil {
+		return time.Now()
+	}
+
+	return clock.Now()
+}
@@ -0,0 +1,103 @@
+package whilst
+
+import (
+	"math"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestFakeClock(t *testing.T) {
+	start := time.Date(2023, time.January, 1, 0, 0, 0, 0, time.UTC)
+	clock := NewFakeClock(start)
+
+	require.Equal(t, start, clock.Now())
+	require.Equal(t, 0, clock.Waiters())
+
+	after := clock.After(time.Hour)
+	timer := clock.NewTimer(2 * time.Hour)
+	stopped := clock.NewTimer(time.Minute)
+
+	require.Equal(t, 3, clock.Waiters())
+	require.True(t, stopped.Stop())
+	require.False(t, stopped.Stop())
+	require.Equal(t, 2, clock.Waiters())
+
+	clock.Advance(time.Hour - 1)
+	require.Empty(t, after)
+	require.Empty(t, timer.C())
+
+	clock.Advance(1)
+	require.Equal(t, start.Add(time.Hour), <-after)
+	require.Empty(t, timer.C())
+	require.Empty(t, stopped.C())
+	require.Equal(t, 1, clock.Waiters())
+
+	require.True(t, timer.Reset(time.Minute))
+	require.Equal(t, 1, clock.Waiters())
+
+	clock.Set(start)
+	require.Empty(t, timer.C())
+
+	clock.Set(start.Add(2 * time.Hour))
+	require.Equal(t, start.Add(2*time.Hour), <-timer.C())
+	require.Equal(t, 0, clock.Waiters())
+	require.False(t, timer.Stop())
+
+	require.False(t, timer.Reset(0))
+	require.Equal(t, start.Add(2*time.Hour), <-timer.C())
+	require.Equal(t, 0, clock.Waiters())
+
+	require.Equal(t, start.Add(2*time.Hour), <-clock.After(-time.Hour))
+}
+
+func TestRealClockAfter(t *testing.T) {
+	clock := RealClock()
+
+	before := clock.Now()
+	fired := receive(t, clock.After(time.Millisecond))
+
+	require.False(t, fired.Before(before.Add(time.Millisecond)))
+}
+
+func TestFromNowAgo(t *testing.T) {
+	clock := NewFakeClock(time.Date(2023, time.March, 31, 12, 0, 0, 0, time.UTC))
+
+	expected := []struct {
+		Whilst  Whilst
+		FromNow time.Time
+		Ago     time.Time
+	}{
+		{
+			Whilst:  Whilst{},
+			FromNow: time.Date(2023, time.March, 31, 12, 0, 0, 0, time.UTC),
+			Ago:     time.Date(2023, time.March, 31, 12, 0, 0, 0, time.UTC),
+		},
+		{
+			Whilst:  Whilst{Months: 1, Nano: time.Hour},
+			FromNow: time.Date(2023, time.May, 1, 13, 0, 0, 0, time.UTC),
+			Ago:     time.Date(2023, time.March, 3, 11, 0, 0, 0, time.UTC),
+		},
+		{
+			Whilst:  Whilst{Years: 1, Weeks: 1, Days: 1, Negative: true},
+			FromNow: time.Date(2022, time.March, 23, 12, 0, 0, 0, time.UTC),
+			Ago:     time.Date(2024, time.April, 8, 12, 0, 0, 0, time.UTC),
+		},
+		{
+			Whilst:  Whilst{Nano: math.MinInt64},
+			FromNow: clock.Now().Add(math.MinInt64),
+			Ago:     clock.Now().Add(math.MaxInt64).Add(1),
+		},
+	}
+
+	for _, item := range expected {
+		require.Equal(t, item.FromNow, item.Whilst.FromNow(clock), "whilst: %v", item.Whilst)
+		require.Equal(t, item.Ago, item.Whilst.Ago(clock), "whilst: %v", item.Whilst)
+	}
+
+	before := time.Now()
+
+	require.False(t, Whilst{Days: 1}.FromNow(nil).Before(before.AddDate(0, 0, 1)))
+	require.False(t, Whilst{Days: 1}.Ago(nil).Before(before.AddDate(0, 0, -1)))
+}
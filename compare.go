@@ -0,0 +1,136 @@
+package whilst
+
+import (
+	"cmp"
+	"time"
+)
+
+const (
+	// Number of months after which the Gregorian calendar repeats.
+	monthsInCycle = 400 * monthsInYear
+	// Number of days in the cycle of the Gregorian calendar.
+	daysInCycle = 146097
+
+	nanoInDay = int64(24 * time.Hour)
+)
+
+// Number of days from the beginning of the cycle of the Gregorian calendar, starting
+// from a leap year divisible by 400, to the beginning of each month of two cycles.
+//
+//nolint:gochecknoglobals // To increase performance
+var cycleMonthStarts = func() [2*monthsInCycle + 1]int64 {
+	starts := [2*monthsInCycle + 1]int64{}
+
+	for id := range 2 * monthsInCycle {
+		year, month := id/monthsInYear, time.Month(id%monthsInYear+1)
+		starts[id+1] = starts[id] + int64(lastDayOfMonth(year, month))
+	}
+
+	return starts
+}()
+
+// Returns the minimum and maximum number of days in the specified number of
+// consecutive months.
+func monthsSpan(months int64) (int64, int64) {
+	cycles, remainder := months/monthsInCycle, months%monthsInCycle
+
+	shortest, longest := cycleMonthStarts[remainder], cycleMonthStarts[remainder]
+
+	for begin := range int64(monthsInCycle) {
+		days := cycleMonthStarts[begin+remainder] - cycleMonthStarts[begin]
+
+		shortest = min(shortest, days)
+		longest = max(longest, days)
+	}
+
+	return cycles*daysInCycle + shortest, cycles*daysInCycle + longest
+}
+
+// Returns the minimum and maximum number of days in the shift by the specified
+// number of months, which can be negative.
+//
+// Shift by months is equal to the number of days between the first days of
+// the initial and target months, regardless of the day of month of the time from,
+// due to the normalization performed by time.AddDate.
+func monthsShift(months int64) (int64, int64) {
+	if months < 0 {
+		shortest, longest := monthsSpan(-months)
+		return -longest, -shortest
+	}
+
+	return monthsSpan(months)
+}
+
+// Returns days and nanoseconds of the shift by weeks, days and nanoseconds, where
+// nanoseconds are in the range [0, 24h).
+func (sgn signed) fixedPart() (int64, int64) {
+	days := sgn.weeks*daysInWeek + sgn.days + sgn.nano/nanoInDay
+	nano := sgn.nano % nanoInDay
+
+	if nano < 0 {
+		days--
+		nano += nanoInDay
+	}
+
+	return days, nano
+}
+
+// Compares two durations relative to the time from.
+//
+// Returns -1 if the first duration is shorter than the second one, 0 if they are
+// equal, and +1 if the first duration is longer than the second one. Negative
+// durations are shorter than positive ones.
+func Compare(first, second Whilst, from time.Time) int {
+	return first.When(from).Compare(second.When(from))
+}
+
+// Reports whether the first duration is shorter than the second one relative to
+// the time from.
+func Less(first, second Whilst, from time.Time) bool {
+	return Compare(first, second, from) < 0
+}
+
+// Reports whether two durations are equal relative to the time from, e.g. 1mo and
+// 31d are equal relative to January 1.
+func Equal(first, second Whilst, from time.Time) bool {
+	return Compare(first, second, from) == 0
+}
+
+// Compares two durations regardless of the time relative to which they occur.
+//
+// Returns the same result as [Compare] would return for any time from in UTC or in
+// another location without daylight saving time transitions. If the result depends
+// on the time from, e.g. for 1mo and 30d, then [ErrIndeterminate] is returned.
+func CompareDefinite(first, second Whilst) (int, error) {
+	fsg := first.signed()
+	ssg := second.signed()
+
+	months := (fsg.years-ssg.years)*monthsInYear + fsg.months - ssg.months
+
+	firstDays, firstNano := fsg.fixedPart()
+	secondDays, secondNano := ssg.fixedPart()
+
+	days := firstDays - secondDays
+	nano := firstNano - secondNano
+
+	shortest, longest := monthsShift(months)
+
+	lower := compareDays(shortest+days, nano)
+	upper := compareDays(longest+days, nano)
+
+	if lower != upper {
+		return 0, ErrIndeterminate
+	}
+
+	return lower, nil
+}
+
+// Returns the sign of the sum of days and nanoseconds that are less than a day in
+// absolute value.
+func compareDays(days int64, nano int64) int {
+	if days != 0 {
+		return cmp.Compare(days, 0)
+	}
+
+	return cmp.Compare(nano, 0)
+}
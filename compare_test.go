@@ -0,0 +1,159 @@
+package whilst
+
+import (
+	"math"
+	"slices"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestCompare(t *testing.T) {
+	january := time.Date(2023, time.January, 1, 0, 0, 0, 0, time.UTC)
+	february := time.Date(2023, time.February, 1, 0, 0, 0, 0, time.UTC)
+
+	require.Equal(t, 0, Compare(Whilst{Months: 1}, Whilst{Days: 31}, january))
+	require.Equal(t, 1, Compare(Whilst{Months: 1}, Whilst{Days: 30}, january))
+	require.Equal(t, -1, Compare(Whilst{Months: 1}, Whilst{Days: 30}, february))
+	require.Equal(t, -1, Compare(Whilst{Days: 1, Negative: true}, Whilst{}, january))
+	require.Equal(t, 1, Compare(Whilst{Years: math.MaxUint16}, Whilst{Nano: math.MaxInt64}, january))
+
+	require.True(t, Less(Whilst{Months: 1}, Whilst{Days: 30}, february))
+	require.False(t, Less(Whilst{Months: 1}, Whilst{Days: 31}, january))
+	require.True(t, Equal(Whilst{Months: 1}, Whilst{Days: 31}, january))
+	require.False(t, Equal(Whilst{Months: 1}, Whilst{Days: 31}, february))
+
+	policies := []Whilst{
+		{Months: 1},
+		{Days: 30},
+		{Weeks: 5},
+		{Nano: 24 * time.Hour},
+	}
+
+	slices.SortFunc(policies, func(first, second Whilst) int {
+		return Compare(first, second, february)
+	})
+
+	require.Equal(t, []Whilst{{Nano: 24 * time.Hour}, {Months: 1}, {Days: 30}, {Weeks: 5}}, policies)
+}
+
+func TestMonthsSpan(t *testing.T) {
+	expected := []struct {
+		Months   int64
+		Shortest int64
+		Longest  int64
+	}{
+		{Months: 0, Shortest: 0, Longest: 0},
+		{Months: 1, Shortest: 28, Longest: 31},
+		{Months: 2, Shortest: 59, Longest: 62},
+		{Months: 12, Shortest: 365, Longest: 366},
+		{Months: 48, Shortest: 1460, Longest: 1461},
+		{Months: 1200, Shortest: 36524, Longest: 36525},
+		{Months: 4800, Shortest: 146097, Longest: 146097},
+		{Months: 4801, Shortest: 146125, Longest: 146128},
+	}
+
+	for _, item := range expected {
+		shortest, longest := monthsSpan(item.Months)
+		require.Equal(t, item.Shortest, shortest, "months: %v", item.Months)
+		require.Equal(t, item.Longest, longest, "months: %v", item.Months)
+	}
+}
+
+func TestCompareDefinite(t *testing.T) {
+	expected := []struct {
+		First    Whilst
+		Second   Whilst
+		Expected int
+		Err      error
+	}{
+		{First: Whilst{}, Second: Whilst{Negative: true}, Expected: 0},
+		{First: Whilst{Months: 18}, Second: Whilst{Years: 1, Months: 6}, Expected: 0},
+		{First: Whilst{Weeks: 1}, Second: Whilst{Days: 7}, Expected: 0},
+		{First: Whilst{Days: 1}, Second: Whilst{Nano: 24 * time.Hour}, Expected: 0},
+		{First: Whilst{Days: 1}, Second: Whilst{Nano: 24*time.Hour - 1}, Expected: 1},
+		{First: Whilst{Months: 1}, Second: Whilst{Days: 28}, Expected: 0, Err: ErrIndeterminate},
+		{First: Whilst{Months: 1}, Second: Whilst{Days: 27, Nano: 24*time.Hour - 1}, Expected: 1},
+		{First: Whilst{Months: 1}, Second: Whilst{Days: 30}, Err: ErrIndeterminate},
+		{First: Whilst{Months: 1}, Second: Whilst{Days: 31}, Err: ErrIndeterminate},
+		{First: Whilst{Months: 1}, Second: Whilst{Days: 31, Nano: 1}, Expected: -1},
+		{First: Whilst{Months: 1}, Second: Whilst{Months: 1, Nano: 1}, Expected: -1},
+		{First: Whilst{Years: 1}, Second: Whilst{Days: 364}, Expected: 1},
+		{First: Whilst{Years: 1}, Second: Whilst{Days: 366}, Err: ErrIndeterminate},
+		{First: Whilst{Years: 1}, Second: Whilst{Days: 367}, Expected: -1},
+		{First: Whilst{Years: 1, Negative: true}, Second: Whilst{Days: 367, Negative: true}, Expected: 1},
+		{First: Whilst{Months: 1, Negative: true}, Second: Whilst{Days: 27, Negative: true}, Expected: -1},
+		{
+			First:    Whilst{Years: 400},
+			Second:   Whilst{Days: 65535, Weeks: 11508, Nano: 144 * time.Hour},
+			Expected: 0,
+		},
+		{First: Whilst{Months: 1}, Second: Whilst{Months: 1, Negative: true}, Expected: 1},
+		{First: Whilst{Nano: math.MinInt64}, Second: Whilst{Nano: math.MaxInt64}, Expected: -1},
+		{
+			First:    Whilst{Years: math.MaxUint16, Months: math.MaxUint16},
+			Second:   Whilst{Years: math.MaxUint16, Months: math.MaxUint16, Negative: true},
+			Expected: 1,
+		},
+	}
+
+	for _, item := range expected {
+		actual, err := CompareDefinite(item.First, item.Second)
+		require.ErrorIs(t, err, item.Err, "first: %+v, second: %+v", item.First, item.Second)
+		require.Equal(t, item.Expected, actual, "first: %+v, second: %+v", item.First, item.Second)
+
+		if item.Err != nil {
+			continue
+		}
+
+		actual, err = CompareDefinite(item.Second, item.First)
+		require.NoError(t, err)
+		require.Equal(t, -item.Expected, actual)
+	}
+}
+
+func FuzzCompareDefinite(f *testing.F) {
+	f.Add(uint16(1), uint16(0), int64(0), false, uint16(0), uint16(30), int64(0), false, int64(0))
+	f.Add(uint16(12), uint16(0), int64(0), false, uint16(0), uint16(365), int64(1), false, int64(1e17))
+	f.Add(uint16(1), uint16(0), int64(1), true, uint16(0), uint16(28), int64(-1), true, int64(-1e17))
+
+	f.Fuzz(
+		func(
+			t *testing.T,
+			firstMonths uint16,
+			firstDays uint16,
+			firstNano int64,
+			firstNegative bool,
+			secondMonths uint16,
+			secondDays uint16,
+			secondNano int64,
+			secondNegative bool,
+			anchor int64,
+		) {
+			first := Whilst{
+				Months:   firstMonths,
+				Days:     firstDays,
+				Nano:     time.Duration(firstNano),
+				Negative: firstNegative,
+			}
+
+			second := Whilst{
+				Months:   secondMonths,
+				Days:     secondDays,
+				Nano:     time.Duration(secondNano),
+				Negative: secondNegative,
+			}
+
+			from := time.Unix(0, anchor).UTC()
+
+			actual, err := CompareDefinite(first, second)
+			if err != nil {
+				require.ErrorIs(t, err, ErrIndeterminate)
+				return
+			}
+
+			require.Equal(t, Compare(first, second, from), actual)
+		},
+	)
+}
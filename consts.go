@@ -1,14 +1,17 @@
 package whilst
 
 const (
+	charComma = ','
 	charDot   = '.'
 	charMinus = '-'
 	charPlus  = '+'
+	charSlash = '/'
 )
 
 const (
 	unitYear          = "y"
 	unitMonth         = "mo"
+	unitWeek          = "w"
 	unitDay           = "d"
 	unitHour          = "h"
 	unitMinute        = "m"
@@ -21,11 +24,46 @@ const (
 )
 
 const (
-	specialZeroFormat = "0s"
-	specialZeroParse  = "0"
+	designatorPeriod = 'P'
+	designatorTime   = 'T'
+	designatorYear   = 'Y'
+	designatorMonth  = 'M'
+	designatorWeek   = 'W'
+	designatorDay    = 'D'
+	designatorHour   = 'H'
+	designatorMinute = 'M'
+	designatorSecond = 'S'
 )
 
+// Ranks of the duration components, in order of decreasing magnitude.
 const (
-	formatMaximum    = "-65535y65535mo65535d2562047h47m16.854775808s"
-	formatMaximumStd = "-2562047h47m16.854775808s"
+	rankNone = iota
+	rankYear
+	rankMonth
+	rankWeek
+	rankDay
+	rankHour
+	rankMinute
+	rankSecond
+)
+
+const (
+	daysInWeek   = 7
+	monthsInYear = 12
+)
+
+const (
+	specialZeroFormat  = "0s"
+	specialZeroParse   = "0"
+	specialZeroISO8601 = "PT0S"
+	specialNullJSON    = "null"
+)
+
+const (
+	formatMaximum        = "-65535y65535mo65535w65535d2562047h47m16.854775808s"
+	formatMaximumStd     = "-2562047h47m16.854775808s"
+	formatMaximumISO8601 = "-P65535Y65535M524280DT2562047H47M16.854775808S"
+
+	formatMaximumPostgres = "@ 65535 years 65535 mons 65535 days 2562047 hours 47 mins " +
+		"16.854775808 secs ago"
 )
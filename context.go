@@ -0,0 +1,135 @@
+package whilst
+
+import (
+	"context"
+	"sync"
+	"time"
+)
+
+// Returns a copy of the parent context with the deadline obtained by shifting
+// the current time of the clock by the duration, e.g. 1mo at January 15 at noon
+// corresponds to the deadline February 15 at noon, regardless of daylight saving time
+// transitions in the location of the current time. If the clock is not specified,
+// the [RealClock] is used.
+//
+// The context expires when the timer of the clock fires, so with the [FakeClock]
+// it expires only when the clock is advanced to the deadline. After the expiration,
+// Err of the context returns [context.DeadlineExceeded], just like with
+// [context.WithDeadline].
+//
+// If the duration is zero or negative, the context is canceled immediately.
+func WithTimeoutWhilst(
+	parent context.Context,
+	whl Whilst,
+	clock Clock,
+) (context.Context, context.CancelFunc) {
+	if clock == nil {
+		clock = RealClock()
+	}
+
+	now := clock.Now()
+
+	inner, cancelInner := context.WithCancelCause(parent)
+
+	ctx := &clockContext{
+		Context:     inner,
+		cancelInner: cancelInner,
+		deadline:    whl.When(now),
+		done:        make(chan struct{}),
+	}
+
+	cancel := func() {
+		ctx.cancel(context.Canceled)
+	}
+
+	if err := parent.Err(); err != nil {
+		ctx.cancel(err)
+		return ctx, cancel
+	}
+
+	timeout := ctx.deadline.Sub(now)
+
+	if timeout <= 0 {
+		ctx.cancel(context.DeadlineExceeded)
+		return ctx, cancel
+	}
+
+	go ctx.wait(parent, clock.NewTimer(timeout))
+
+	return ctx, cancel
+}
+
+// Returns a copy of the parent context with the deadline obtained by shifting
+// the time from by the duration.
+//
+// If the deadline has already passed, the context is canceled immediately.
+func WithDeadlineWhilst(
+	parent context.Context,
+	whl Whilst,
+	from time.Time,
+) (context.Context, context.CancelFunc) {
+	return context.WithDeadline(parent, whl.When(from))
+}
+
+// Context that expires according to the timer of a clock.
+//
+// Values and the cause of the cancellation are provided by the inner context, which
+// is canceled together with this one.
+type clockContext struct {
+	context.Context
+
+	cancelInner context.CancelCauseFunc
+	deadline    time.Time
+	done        chan struct{}
+
+	mutex sync.Mutex
+	err   error
+}
+
+func (ctx *clockContext) Deadline() (time.Time, bool) {
+	if deadline, exist := ctx.Context.Deadline(); exist && deadline.Before(ctx.deadline) {
+		return deadline, true
+	}
+
+	return ctx.deadline, true
+}
+
+func (ctx *clockContext) Done() <-chan struct{} {
+	return ctx.done
+}
+
+func (ctx *clockContext) Err() error {
+	ctx.mutex.Lock()
+	defer ctx.mutex.Unlock()
+
+	return ctx.err
+}
+
+// Waits for the timer to fire or for the inner context to be canceled, which
+// happens when the parent context is done or this context is canceled.
+func (ctx *clockContext) wait(parent context.Context, timer ClockTimer) {
+	defer timer.Stop()
+
+	select {
+	case <-timer.C():
+		ctx.cancel(context.DeadlineExceeded)
+	case <-ctx.Context.Done():
+		ctx.cancel(parent.Err())
+	}
+}
+
+// Cancels the context with the error, if it has not been canceled yet.
+func (ctx *clockContext) cancel(err error) {
+	ctx.mutex.Lock()
+	defer ctx.mutex.Unlock()
+
+	if ctx.err != nil {
+		return
+	}
+
+	ctx.cancelInner(err)
+
+	ctx.err = err
+
+	close(ctx.done)
+}
@@ -0,0 +1,178 @@
+package whilst
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestWithTimeoutWhilst(t *testing.T) {
+	location := testLocation(t)
+
+	expected := []struct {
+		Now      time.Time
+		Whilst   Whilst
+		Deadline time.Time
+	}{
+		{
+			Now:      time.Date(2023, time.January, 31, 12, 0, 0, 0, location),
+			Whilst:   Whilst{Months: 1},
+			Deadline: time.Date(2023, time.March, 3, 12, 0, 0, 0, location),
+		},
+		{
+			// Transition to the summer time occurs on March 26, 2023
+			Now:      time.Date(2023, time.March, 25, 12, 0, 0, 0, location),
+			Whilst:   Whilst{Days: 1, Nano: 12 * time.Hour},
+			Deadline: time.Date(2023, time.March, 27, 0, 0, 0, 0, location),
+		},
+	}
+
+	for _, item := range expected {
+		ctx, cancel := WithTimeoutWhilst(context.Background(), item.Whilst, NewFakeClock(item.Now))
+		defer cancel()
+
+		deadline, exist := ctx.Deadline()
+		require.True(t, exist)
+		require.Equal(t, item.Deadline, deadline, "whilst: %v", item.Whilst)
+	}
+
+	ctx, cancel := WithTimeoutWhilst(context.Background(), Whilst{Days: 1, Negative: true}, nil)
+	defer cancel()
+
+	require.ErrorIs(t, ctx.Err(), context.DeadlineExceeded)
+
+	ctx, cancel = WithTimeoutWhilst(context.Background(), Whilst{Nano: time.Millisecond}, nil)
+	defer cancel()
+
+	select {
+	case <-ctx.Done():
+	case <-time.After(testTimeout):
+		require.FailNow(t, "context was not expired")
+	}
+
+	require.ErrorIs(t, ctx.Err(), context.DeadlineExceeded)
+}
+
+func TestWithTimeoutWhilstClock(t *testing.T) {
+	clock := NewFakeClock(time.Date(2023, time.January, 31, 12, 0, 0, 0, time.UTC))
+
+	ctx, cancel := WithTimeoutWhilst(context.Background(), Whilst{Months: 1}, clock)
+	defer cancel()
+
+	child, cancelChild := context.WithCancel(ctx)
+	defer cancelChild()
+
+	// Timer is created before the context is returned
+	require.Equal(t, 1, clock.Waiters())
+
+	clock.Advance(31*24*time.Hour - time.Nanosecond)
+
+	require.NoError(t, ctx.Err())
+
+	select {
+	case <-ctx.Done():
+		require.FailNow(t, "context was expired before the deadline")
+	default:
+	}
+
+	clock.Advance(time.Nanosecond)
+
+	select {
+	case <-ctx.Done():
+	case <-time.After(testTimeout):
+		require.FailNow(t, "context was not expired")
+	}
+
+	require.ErrorIs(t, ctx.Err(), context.DeadlineExceeded)
+	require.ErrorIs(t, context.Cause(ctx), context.DeadlineExceeded)
+
+	select {
+	case <-child.Done():
+	case <-time.After(testTimeout):
+		require.FailNow(t, "child context was not expired")
+	}
+
+	require.ErrorIs(t, child.Err(), context.DeadlineExceeded)
+
+	cancel()
+
+	require.ErrorIs(t, ctx.Err(), context.DeadlineExceeded)
+	require.Equal(t, 0, clock.Waiters())
+}
+
+func TestWithTimeoutWhilstCancel(t *testing.T) {
+	clock := NewFakeClock(time.Date(2023, time.January, 31, 12, 0, 0, 0, time.UTC))
+
+	ctx, cancel := WithTimeoutWhilst(context.Background(), Whilst{Days: 1}, clock)
+
+	cancel()
+
+	<-ctx.Done()
+
+	require.ErrorIs(t, ctx.Err(), context.Canceled)
+	require.Eventually(t, func() bool { return clock.Waiters() == 0 }, testTimeout, time.Millisecond)
+
+	parent, cancelParent := context.WithCancel(context.Background())
+
+	ctx, cancel = WithTimeoutWhilst(parent, Whilst{Days: 1}, clock)
+	defer cancel()
+
+	cancelParent()
+
+	select {
+	case <-ctx.Done():
+	case <-time.After(testTimeout):
+		require.FailNow(t, "context was not canceled")
+	}
+
+	require.ErrorIs(t, ctx.Err(), context.Canceled)
+
+	ctx, cancel = WithTimeoutWhilst(parent, Whilst{Days: 1}, clock)
+	defer cancel()
+
+	require.ErrorIs(t, ctx.Err(), context.Canceled)
+
+	// Deadline of the parent context is earlier
+	parent, cancelParent = context.WithDeadline(context.Background(), clock.Now().Add(time.Hour))
+	defer cancelParent()
+
+	ctx, cancel = WithTimeoutWhilst(parent, Whilst{Days: 1}, clock)
+	defer cancel()
+
+	deadline, exist := ctx.Deadline()
+	require.True(t, exist)
+	require.Equal(t, clock.Now().Add(time.Hour), deadline)
+}
+
+func TestWithDeadlineWhilst(t *testing.T) {
+	from := time.Date(2024, time.February, 29, 0, 0, 0, 0, time.UTC)
+
+	parent, cancelParent := WithDeadlineWhilst(context.Background(), Whilst{Years: 100}, from)
+	defer cancelParent()
+
+	ctx, cancel := WithDeadlineWhilst(parent, Whilst{Years: 1}, from)
+	defer cancel()
+
+	deadline, exist := ctx.Deadline()
+	require.True(t, exist)
+	require.Equal(t, time.Date(2025, time.March, 1, 0, 0, 0, 0, time.UTC), deadline)
+
+	// Deadline of the parent context is earlier
+	ctx, cancel = WithDeadlineWhilst(parent, Whilst{Years: 200}, from)
+	defer cancel()
+
+	deadline, exist = ctx.Deadline()
+	require.True(t, exist)
+	require.Equal(t, time.Date(2124, time.February, 29, 0, 0, 0, 0, time.UTC), deadline)
+
+	cancelParent()
+
+	require.ErrorIs(t, ctx.Err(), context.Canceled)
+
+	ctx, cancel = WithDeadlineWhilst(context.Background(), Whilst{Months: 1}, from)
+	defer cancel()
+
+	require.ErrorIs(t, ctx.Err(), context.DeadlineExceeded)
+}
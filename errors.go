@@ -1,14 +1,62 @@
 package whilst
 
-import "errors"
+import (
+	"errors"
+	"fmt"
+)
 
 var (
 	ErrCharDotAgain      = errors.New("dot character was specified again")
 	ErrCharSignAgain     = errors.New("sign character was specified again")
+	ErrDateAfterTime     = errors.New("date values cannot be specified after time designator")
+	ErrDayNonexistent    = errors.New("day of month does not exist in the target month")
+	ErrDesignatorOrder   = errors.New("designator was specified again or out of order")
+	ErrIndeterminate     = errors.New("order of durations depends on the time from")
+	ErrInexactDivision   = errors.New("duration cannot be divided exactly")
 	ErrInputEmpty        = errors.New("input string is empty")
+	ErrInvalidUnit       = errors.New("unit definition is invalid")
+	ErrMixedSigns        = errors.New("components with different signs cannot be represented")
+	ErrNotPositive       = errors.New("duration is not positive")
 	ErrNumberUnspecified = errors.New("number was not specified")
-	ErrOnlyInteger       = errors.New("years, months and days can only be integer")
+	ErrOnlyInteger       = errors.New("years, months, weeks and days can only be integer")
+	ErrOnlyLastFraction  = errors.New("only the last value can have a fractional part")
+	ErrPeriodUnspecified = errors.New("period designator was not specified")
+	ErrSlashUnspecified  = errors.New("interval separator was not specified")
+	ErrTimeAgain         = errors.New("time designator was specified again")
+	ErrTimeUnspecified   = errors.New("time values cannot be specified without time designator")
 	ErrUnexpectedChar    = errors.New("unexpected character was specified")
 	ErrUnexpectedUnit    = errors.New("unexpected unit was specified")
+	ErrUnitRequired      = errors.New("each component must have a unit with factor of one")
 	ErrUnitUnspecified   = errors.New("unit was not specified")
+	ErrUnsupportedType   = errors.New("unsupported type of value")
+	ErrValueUnspecified  = errors.New("value was not specified after designator")
 )
+
+// Error that occurred while parsing a string representation of the duration.
+//
+// Underlying error can be checked using [errors.Is], e.g. errors.Is(err,
+// ErrUnexpectedUnit).
+type ParseError struct {
+	// Input string
+	Input string
+	// Byte offset of the offending token in the input string
+	Offset int
+	// Offending token, may be empty if the input string ended unexpectedly
+	Token string
+	// Underlying error
+	Err error
+}
+
+func (err *ParseError) Error() string {
+	return fmt.Sprintf(
+		"%v: token %q at offset %d of input %q",
+		err.Err,
+		err.Token,
+		err.Offset,
+		err.Input,
+	)
+}
+
+func (err *ParseError) Unwrap() error {
+	return err.Err
+}
@@ -0,0 +1,215 @@
+package whilst
+
+import (
+	"testing"
+
+	"github.com/akramarenkov/safe"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseErrorPosition(t *testing.T) {
+	expected := []struct {
+		Input  string
+		Offset int
+		Token  string
+		Err    error
+	}{
+		{"", 0, "", ErrInputEmpty},
+		{"   ", 3, "", ErrInputEmpty},
+		{" - - 0s", 3, "-", ErrCharSignAgain},
+		{" x1s", 1, "x", ErrUnexpectedChar},
+		{"-৩s", 1, "৩", ErrUnexpectedChar},
+		{"1s s", 3, "s", ErrNumberUnspecified},
+		{"1h 2x3s", 4, "x", ErrUnexpectedUnit},
+		{"1h2ä", 3, "ä", ErrUnexpectedUnit},
+		{"- 1h 2.5d", 5, "2.5d", ErrOnlyInteger},
+		{"1h 2..5s", 5, ".", ErrCharDotAgain},
+		{"1h 25", 3, "25", ErrUnitUnspecified},
+		{"1h 25 ", 3, "25", ErrUnitUnspecified},
+		{"65535d 1d", 7, "1d", safe.ErrOverflow},
+		{"1s 18446744073709551616s", 3, "18446744073709551616", safe.ErrOverflow},
+		{"1s 18446744073709551615h", 3, "18446744073709551615h", safe.ErrOverflow},
+	}
+
+	for _, item := range expected {
+		_, err := Parse(item.Input)
+		require.ErrorIs(t, err, item.Err, "input: %q", item.Input)
+
+		var parseErr *ParseError
+
+		require.ErrorAs(t, err, &parseErr, "input: %q", item.Input)
+		require.Equal(t, item.Input, parseErr.Input, "input: %q", item.Input)
+		require.Equal(t, item.Offset, parseErr.Offset, "input: %q", item.Input)
+		require.Equal(t, item.Token, parseErr.Token, "input: %q", item.Input)
+		require.Equal(t, item.Err, parseErr.Err, "input: %q", item.Input)
+	}
+}
+
+func TestParseErrorPositionISO8601(t *testing.T) {
+	expected := []struct {
+		Input  string
+		Offset int
+		Token  string
+		Err    error
+	}{
+		{"", 0, "", ErrInputEmpty},
+		{"-", 1, "", ErrPeriodUnspecified},
+		{"-1Y", 1, "1", ErrPeriodUnspecified},
+		{"P", 1, "", ErrValueUnspecified},
+		{"PT1H2", 4, "2", ErrUnitUnspecified},
+		{"P1Y2X", 3, "2X", ErrUnexpectedChar},
+		{"P1Y.5M", 3, ".", ErrNumberUnspecified},
+		{"-P1Y2.5M", 4, "2.5M", ErrOnlyInteger},
+		{"P1DT1HT", 6, "T", ErrTimeAgain},
+		{"P1D1Y", 3, "1Y", ErrDesignatorOrder},
+		{"P65536Y", 1, "65536Y", safe.ErrOverflow},
+	}
+
+	for _, item := range expected {
+		_, err := ParseISO8601(item.Input)
+		require.ErrorIs(t, err, item.Err, "input: %q", item.Input)
+
+		var parseErr *ParseError
+
+		require.ErrorAs(t, err, &parseErr, "input: %q", item.Input)
+		require.Equal(t, item.Input, parseErr.Input, "input: %q", item.Input)
+		require.Equal(t, item.Offset, parseErr.Offset, "input: %q", item.Input)
+		require.Equal(t, item.Token, parseErr.Token, "input: %q", item.Input)
+		require.Equal(t, item.Err, parseErr.Err, "input: %q", item.Input)
+	}
+}
+
+func TestParseErrorPositionPostgres(t *testing.T) {
+	expected := []struct {
+		Input  string
+		Offset int
+		Token  string
+		Err    error
+	}{
+		{"", 0, "", ErrInputEmpty},
+		{"   ", 3, "", ErrInputEmpty},
+		{"1 year -2 mons", 7, "-2", ErrMixedSigns},
+		{"1 fortnight", 2, "fortnight", ErrUnexpectedUnit},
+		{"1 year  2", 8, "2", ErrUnitUnspecified},
+		{"1.5 years", 0, "1.5", ErrOnlyInteger},
+		{"@ ago", 5, "", ErrNumberUnspecified},
+		{"@ 1 x", 4, "x", ErrUnexpectedUnit},
+		{"1-2.5", 0, "1-2.5", ErrOnlyInteger},
+		{"1 04::06", 2, "04::06", ErrNumberUnspecified},
+		{"  65536 years", 2, "65536", safe.ErrOverflow},
+		{" 9363 weeks 5 days ", 1, "9363 weeks 5 days", safe.ErrOverflow},
+		{" P1Y-2M", 4, "-2M", ErrMixedSigns},
+		{"P1X", 1, "1X", ErrUnexpectedChar},
+		{"P-1Y-2X", 5, "2X", ErrUnexpectedChar},
+		{"P-1Y-1.5M", 5, "1.5M", ErrOnlyInteger},
+		{"P-1Y-1", 5, "1", ErrUnitUnspecified},
+	}
+
+	for _, item := range expected {
+		_, err := ParsePostgresInterval(item.Input)
+		require.ErrorIs(t, err, item.Err, "input: %q", item.Input)
+
+		var parseErr *ParseError
+
+		require.ErrorAs(t, err, &parseErr, "input: %q", item.Input)
+		require.Equal(t, item.Input, parseErr.Input, "input: %q", item.Input)
+		require.Equal(t, item.Offset, parseErr.Offset, "input: %q", item.Input)
+		require.Equal(t, item.Token, parseErr.Token, "input: %q", item.Input)
+		require.Equal(t, item.Err, parseErr.Err, "input: %q", item.Input)
+	}
+}
+
+func TestParseErrorPositionHumanized(t *testing.T) {
+	expected := []struct {
+		Input  string
+		Offset int
+		Token  string
+		Err    error
+	}{
+		{"", 0, "", ErrInputEmpty},
+		{"   ", 3, "", ErrInputEmpty},
+		{" - and ", 7, "", ErrNumberUnspecified},
+		{"2 years, 3", 9, "3", ErrUnitUnspecified},
+		{"2 years and 3 fortnights", 14, "fortnights", ErrUnexpectedUnit},
+		{" -1 day, -2 years", 9, "-2", ErrUnexpectedChar},
+		{"  1.5 years", 2, "1.5", ErrOnlyInteger},
+		{"1 day and 1..5 hours", 10, "1..5", ErrCharDotAgain},
+		{"1 year, 65536 days", 8, "65536", safe.ErrOverflow},
+	}
+
+	for _, item := range expected {
+		_, err := ParseHumanized(item.Input, HumanizeOptions{})
+		require.ErrorIs(t, err, item.Err, "input: %q", item.Input)
+
+		var parseErr *ParseError
+
+		require.ErrorAs(t, err, &parseErr, "input: %q", item.Input)
+		require.Equal(t, item.Input, parseErr.Input, "input: %q", item.Input)
+		require.Equal(t, item.Offset, parseErr.Offset, "input: %q", item.Input)
+		require.Equal(t, item.Token, parseErr.Token, "input: %q", item.Input)
+		require.Equal(t, item.Err, parseErr.Err, "input: %q", item.Input)
+	}
+}
+
+func TestParseErrorMessage(t *testing.T) {
+	_, err := Parse("1h 2x3s")
+	require.EqualError(
+		t,
+		err,
+		`unexpected unit was specified: token "x" at offset 4 of input "1h 2x3s"`,
+	)
+
+	_, err = ParsePostgresInterval("1 fortnight")
+	require.EqualError(
+		t,
+		err,
+		`unexpected unit was specified: token "fortnight" at offset 2 of input "1 fortnight"`,
+	)
+}
+
+func FuzzParseError(f *testing.F) {
+	f.Add(" - 2y 3mo 1w 10d 23.5h 59.5m 58.01003001s 10ms 30µs 10ns")
+	f.Add("1h 2..5s")
+	f.Add("-P1Y2.5M")
+	f.Add("@ 1 year -2 mons ago")
+	f.Add("P-1Y-2.5M")
+	f.Add(" -2 years, 3 months and 1.5 hours")
+	f.Add("2023-01-01T00:00:00Z/-P65535Y65535M")
+
+	f.Fuzz(
+		func(t *testing.T, input string) {
+			check := func(err error) {
+				if err == nil {
+					return
+				}
+
+				var parseErr *ParseError
+
+				require.ErrorAs(t, err, &parseErr)
+				require.Equal(t, input, parseErr.Input)
+				require.GreaterOrEqual(t, parseErr.Offset, 0)
+				require.LessOrEqual(t, parseErr.Offset+len(parseErr.Token), len(input))
+				require.Equal(
+					t,
+					parseErr.Token,
+					input[parseErr.Offset:parseErr.Offset+len(parseErr.Token)],
+				)
+			}
+
+			_, err := Parse(input)
+			check(err)
+
+			_, err = ParseISO8601(input)
+			check(err)
+
+			_, err = ParsePostgresInterval(input)
+			check(err)
+
+			_, err = ParseHumanized(input, HumanizeOptions{})
+			check(err)
+
+			_, err = ParseInterval(input)
+			check(err)
+		},
+	)
+}
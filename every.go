@@ -0,0 +1,93 @@
+package whilst
+
+import (
+	"iter"
+	"time"
+
+	"github.com/akramarenkov/safe"
+)
+
+// Options of the recurrence of the duration.
+//
+// Zero value of the options corresponds to the unlimited recurrence.
+type EveryOptions struct {
+	// Occurrences after this time, or before it for a negative duration, are not
+	// yielded. No limit if the time is zero
+	Until time.Time
+	// Maximum number of yielded occurrences. No limit if it is not positive
+	Count int
+}
+
+// Returns a sequence of occurrences of the duration starting from the time from,
+// see [EveryWith].
+func Every(whl Whilst, from time.Time) iter.Seq[time.Time] {
+	return EveryWith(whl, from, EveryOptions{})
+}
+
+// Returns a sequence of occurrences of the duration starting from the time from
+// taking into account the options.
+//
+// The n-th occurrence is calculated as the time from shifted by the duration
+// multiplied by n, just like [Whilst.When] does, rather than by shifting the previous
+// occurrence, so the occurrences do not drift, e.g. 1mo starting from January 31
+// yields January 31, March 3, March 31, May 1 and so on. The first occurrence is
+// the time from itself.
+//
+// For a negative duration, occurrences go backward in time. For a zero duration,
+// only the time from is yielded. The sequence ends when an occurrence cannot be
+// calculated due to an overflow.
+func EveryWith(whl Whilst, from time.Time, opts EveryOptions) iter.Seq[time.Time] {
+	sgn := whl.signed()
+	backward := whl.IsNegative()
+	once := whl.IsZero()
+
+	seq := func(yield func(time.Time) bool) {
+		for number := int64(0); opts.Count <= 0 || number < int64(opts.Count); number++ {
+			occurrence, err := sgn.occurrence(from, number)
+			if err != nil {
+				return
+			}
+
+			if !opts.Until.IsZero() {
+				if backward && occurrence.Before(opts.Until) {
+					return
+				}
+
+				if !backward && occurrence.After(opts.Until) {
+					return
+				}
+			}
+
+			if !yield(occurrence) || once {
+				return
+			}
+		}
+	}
+
+	return seq
+}
+
+// Returns the time from shifted by the duration multiplied by the specified number.
+func (sgn signed) occurrence(from time.Time, number int64) (time.Time, error) {
+	years, err := safe.Mul(sgn.years, number)
+	if err != nil {
+		return time.Time{}, err
+	}
+
+	months, err := safe.Mul(sgn.months, number)
+	if err != nil {
+		return time.Time{}, err
+	}
+
+	days, err := safe.Mul(sgn.weeks*daysInWeek+sgn.days, number)
+	if err != nil {
+		return time.Time{}, err
+	}
+
+	nano, err := safe.Mul(sgn.nano, number)
+	if err != nil {
+		return time.Time{}, err
+	}
+
+	return from.AddDate(int(years), int(months), int(days)).Add(time.Duration(nano)), nil
+}
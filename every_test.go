@@ -0,0 +1,177 @@
+package whilst
+
+import (
+	"math"
+	"slices"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestEvery(t *testing.T) {
+	from := time.Date(2023, time.January, 31, 12, 0, 0, 0, time.UTC)
+
+	expected := []time.Time{
+		from,
+		time.Date(2023, time.March, 3, 12, 0, 0, 0, time.UTC),
+		time.Date(2023, time.March, 31, 12, 0, 0, 0, time.UTC),
+		time.Date(2023, time.May, 1, 12, 0, 0, 0, time.UTC),
+		time.Date(2023, time.May, 31, 12, 0, 0, 0, time.UTC),
+	}
+
+	actual := make([]time.Time, 0, len(expected))
+
+	for occurrence := range Every(Whilst{Months: 1}, from) {
+		actual = append(actual, occurrence)
+
+		if len(actual) == len(expected) {
+			break
+		}
+	}
+
+	require.Equal(t, expected, actual)
+}
+
+func TestEveryWith(t *testing.T) {
+	from := time.Date(2024, time.February, 29, 0, 0, 0, 0, time.UTC)
+
+	expected := []struct {
+		Whilst   Whilst
+		Options  EveryOptions
+		Expected []time.Time
+	}{
+		{
+			Whilst:  Whilst{Years: 1},
+			Options: EveryOptions{Count: 3},
+			Expected: []time.Time{
+				from,
+				time.Date(2025, time.March, 1, 0, 0, 0, 0, time.UTC),
+				time.Date(2026, time.March, 1, 0, 0, 0, 0, time.UTC),
+			},
+		},
+		{
+			Whilst:  Whilst{Weeks: 1, Nano: 12 * time.Hour},
+			Options: EveryOptions{Until: time.Date(2024, time.March, 15, 0, 0, 0, 0, time.UTC)},
+			Expected: []time.Time{
+				from,
+				time.Date(2024, time.March, 7, 12, 0, 0, 0, time.UTC),
+				time.Date(2024, time.March, 15, 0, 0, 0, 0, time.UTC),
+			},
+		},
+		{
+			Whilst: Whilst{Months: 1, Negative: true},
+			Options: EveryOptions{
+				Until: time.Date(2023, time.November, 29, 0, 0, 0, 1, time.UTC),
+				Count: 10,
+			},
+			Expected: []time.Time{
+				from,
+				time.Date(2024, time.January, 29, 0, 0, 0, 0, time.UTC),
+				time.Date(2023, time.December, 29, 0, 0, 0, 0, time.UTC),
+			},
+		},
+		{
+			Whilst:  Whilst{Nano: -time.Hour},
+			Options: EveryOptions{Count: 2},
+			Expected: []time.Time{
+				from,
+				from.Add(-time.Hour),
+			},
+		},
+		{
+			Whilst:   Whilst{Negative: true},
+			Expected: []time.Time{from},
+		},
+		{
+			Whilst:   Whilst{Days: 1},
+			Options:  EveryOptions{Until: from.Add(-1)},
+			Expected: nil,
+		},
+		{
+			Whilst:   Whilst{Nano: math.MaxInt64},
+			Expected: []time.Time{from, from.Add(math.MaxInt64)},
+		},
+		{
+			Whilst:   Whilst{Nano: math.MinInt64 / 2},
+			Expected: []time.Time{from, from.Add(math.MinInt64 / 2), from.Add(math.MinInt64)},
+		},
+	}
+
+	for _, item := range expected {
+		require.Equal(
+			t,
+			item.Expected,
+			slices.Collect(EveryWith(item.Whilst, from, item.Options)),
+			"whilst: %v",
+			item.Whilst,
+		)
+	}
+}
+
+func TestEveryLocation(t *testing.T) {
+	location, err := time.LoadLocation("Europe/Berlin")
+	require.NoError(t, err)
+
+	from := time.Date(2023, time.March, 25, 12, 0, 0, 0, location)
+
+	expected := []time.Time{
+		from,
+		time.Date(2023, time.March, 26, 12, 0, 0, 0, location),
+		time.Date(2023, time.March, 27, 12, 0, 0, 0, location),
+	}
+
+	require.Equal(t, expected, slices.Collect(EveryWith(Whilst{Days: 1}, from, EveryOptions{Count: 3})))
+}
+
+func FuzzEvery(f *testing.F) {
+	f.Add(uint16(0), uint16(1), uint16(0), uint16(0), int64(0), false, int64(0), uint8(10))
+	f.Add(uint16(1), uint16(0), uint16(1), uint16(2), int64(time.Hour), true, int64(1e17), uint8(5))
+	f.Add(uint16(0), uint16(0), uint16(0), uint16(0), int64(-1), false, int64(-1e17), uint8(3))
+
+	f.Fuzz(
+		func(
+			t *testing.T,
+			years uint16,
+			months uint16,
+			weeks uint16,
+			days uint16,
+			nano int64,
+			negative bool,
+			anchor int64,
+			count uint8,
+		) {
+			whl := Whilst{
+				Years:    years,
+				Months:   months,
+				Weeks:    weeks,
+				Days:     days,
+				Nano:     time.Duration(nano),
+				Negative: negative,
+			}
+
+			from := time.Unix(0, anchor).UTC()
+
+			opts := EveryOptions{
+				Count: int(count) + 1,
+			}
+
+			occurrences := slices.Collect(EveryWith(whl, from, opts))
+
+			require.LessOrEqual(t, len(occurrences), opts.Count)
+			require.Equal(t, from, occurrences[0])
+
+			if len(occurrences) > 1 {
+				require.Equal(t, whl.When(from), occurrences[1])
+			}
+
+			for id := 1; id < len(occurrences); id++ {
+				if whl.IsNegative() {
+					require.True(t, occurrences[id].Before(occurrences[id-1]))
+				} else {
+					require.True(t, occurrences[id].After(occurrences[id-1]))
+				}
+			}
+		},
+	)
+}
@@ -0,0 +1,54 @@
+package whilst
+
+import "flag"
+
+// Parses a string representation of the duration, in the format of [Parse],
+// and sets the result as a value of the duration.
+//
+// Implements the [flag.Value] interface. In case of an error, the duration remains
+// unchanged.
+func (whl *Whilst) Set(input string) error {
+	parsed, err := Parse(input)
+	if err != nil {
+		return err
+	}
+
+	*whl = parsed
+
+	return nil
+}
+
+// Returns a value of the duration.
+//
+// Implements the [flag.Getter] interface.
+func (whl *Whilst) Get() any {
+	return *whl
+}
+
+// Defines a duration flag with the specified name, default value and usage string.
+// The return value is the address of a duration variable that stores the value of
+// the flag.
+//
+// If the flag set is nil, then [flag.CommandLine] is used.
+func Flag(fs *flag.FlagSet, name string, value Whilst, usage string) *Whilst {
+	whl := new(Whilst)
+
+	FlagVar(fs, whl, name, value, usage)
+
+	return whl
+}
+
+// Defines a duration flag with the specified name, default value and usage string.
+// The argument whl points to a duration variable in which to store the value of
+// the flag.
+//
+// If the flag set is nil, then [flag.CommandLine] is used.
+func FlagVar(fs *flag.FlagSet, whl *Whilst, name string, value Whilst, usage string) {
+	if fs == nil {
+		fs = flag.CommandLine
+	}
+
+	*whl = value
+
+	fs.Var(whl, name, usage)
+}
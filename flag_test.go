@@ -0,0 +1,106 @@
+package whilst
+
+import (
+	"bytes"
+	"flag"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestFlagInterfaces(t *testing.T) {
+	var (
+		_ flag.Value  = &Whilst{}
+		_ flag.Getter = &Whilst{}
+	)
+}
+
+func TestFlag(t *testing.T) {
+	fs := flag.NewFlagSet("test", flag.ContinueOnError)
+
+	retention := Flag(fs, "retention", Whilst{Years: 1}, "retention period")
+	timeout := Flag(fs, "timeout", Whilst{Nano: time.Minute}, "timeout")
+	unset := Flag(fs, "unset", Whilst{Days: 3}, "unset")
+
+	var extension Whilst
+
+	FlagVar(fs, &extension, "extension", Whilst{}, "extension period")
+
+	err := fs.Parse(
+		[]string{
+			"--retention", "1y6mo",
+			"-timeout=1.5h",
+			"-extension", "- 14d",
+		},
+	)
+	require.NoError(t, err)
+
+	require.Equal(t, Whilst{Years: 1, Months: 6}, *retention)
+	require.Equal(t, Whilst{Nano: 90 * time.Minute}, *timeout)
+	require.Equal(t, Whilst{Days: 3}, *unset)
+	require.Equal(t, Whilst{Days: 14, Negative: true}, extension)
+
+	getter, casted := fs.Lookup("retention").Value.(flag.Getter)
+	require.True(t, casted)
+	require.Equal(t, Whilst{Years: 1, Months: 6}, getter.Get())
+
+	require.Equal(t, "1y6mo", fs.Lookup("retention").Value.String())
+	require.Equal(t, "1y", fs.Lookup("retention").DefValue)
+}
+
+func TestFlagError(t *testing.T) {
+	fs := flag.NewFlagSet("test", flag.ContinueOnError)
+	fs.SetOutput(&bytes.Buffer{})
+
+	retention := Flag(fs, "retention", Whilst{Years: 1}, "retention period")
+
+	require.Error(t, fs.Parse([]string{"-retention", "1x"}))
+	require.Equal(t, Whilst{Years: 1}, *retention)
+}
+
+func TestFlagUsage(t *testing.T) {
+	fs := flag.NewFlagSet("test", flag.ContinueOnError)
+
+	_ = Flag(fs, "retention", Whilst{Years: 1}, "retention `period`")
+	_ = Flag(fs, "zero", Whilst{}, "zero period")
+
+	output := &bytes.Buffer{}
+
+	fs.SetOutput(output)
+	fs.PrintDefaults()
+
+	require.Equal(
+		t,
+		"  -retention period\n"+
+			"    \tretention period (default 1y)\n"+
+			"  -zero value\n"+
+			"    \tzero period\n",
+		output.String(),
+	)
+}
+
+func TestFlagCommandLine(t *testing.T) {
+	origin := flag.CommandLine
+
+	defer func() {
+		flag.CommandLine = origin
+	}()
+
+	flag.CommandLine = flag.NewFlagSet("test", flag.ContinueOnError)
+
+	retention := Flag(nil, "retention", Whilst{Years: 1}, "retention period")
+
+	require.NoError(t, flag.CommandLine.Parse([]string{"-retention", "2y"}))
+	require.Equal(t, Whilst{Years: 2}, *retention)
+}
+
+func TestSet(t *testing.T) {
+	whl := Whilst{Years: 1}
+
+	require.Error(t, whl.Set("1x"))
+	require.Equal(t, Whilst{Years: 1}, whl)
+
+	require.NoError(t, whl.Set("2mo"))
+	require.Equal(t, Whilst{Months: 2}, whl)
+}
@@ -1,6 +1,9 @@
 package whilst
 
-import "github.com/akramarenkov/whilst/internal/ascii"
+import (
+	"github.com/akramarenkov/whilst/internal/ascii"
+	"github.com/akramarenkov/whilst/internal/consts"
+)
 
 const (
 	fractionLength = 9
@@ -32,3 +35,18 @@ func appendFraction(output []byte, fraction uint64) []byte {
 
 	return output
 }
+
+// Splits the absolute value of nanoseconds into hours, minutes, seconds and
+// a fraction of a second in nanoseconds.
+func splitNano(duration uint64) (uint64, uint64, uint64, uint64) {
+	hours := duration / consts.U64Hour
+	duration %= consts.U64Hour
+
+	minutes := duration / consts.U64Minute
+	duration %= consts.U64Minute
+
+	seconds := duration / consts.U64Second
+	duration %= consts.U64Second
+
+	return hours, minutes, seconds, duration
+}
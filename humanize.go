@@ -0,0 +1,359 @@
+package whilst
+
+import (
+	"slices"
+	"strconv"
+	"strings"
+	"unicode"
+
+	"github.com/akramarenkov/whilst/internal/consts"
+
+	"github.com/akramarenkov/safe"
+)
+
+// Short units corresponding to units of the long-form representation.
+//
+//nolint:gochecknoglobals // To increase performance
+var shortUnits = [unitsQuantity]string{
+	UnitYear:        unitYear,
+	UnitMonth:       unitMonth,
+	UnitWeek:        unitWeek,
+	UnitDay:         unitDay,
+	UnitHour:        unitHour,
+	UnitMinute:      unitMinute,
+	UnitSecond:      unitSecond,
+	UnitMillisecond: unitMillisecond,
+	UnitMicrosecond: unitMicrosecond,
+	UnitNanosecond:  unitNanosecond,
+}
+
+// Dimensions of the components that are stored in nanoseconds.
+//
+//nolint:gochecknoglobals // To increase performance
+var humanDimensions = [unitsQuantity]uint64{
+	UnitHour:        consts.U64Hour,
+	UnitMinute:      consts.U64Minute,
+	UnitSecond:      consts.U64Second,
+	UnitMillisecond: consts.U64Millisecond,
+	UnitMicrosecond: consts.U64Microsecond,
+	UnitNanosecond:  consts.U64Nanosecond,
+}
+
+// Options of the human-readable representation of the duration.
+//
+// Zero value of the options corresponds to the default representation, e.g.
+// 2 years, 3 months and 10 days.
+type HumanizeOptions struct {
+	// Locale of names of units, [English] by default
+	Locale Locale
+	// Separator between components, the default one of the locale by default
+	Separator string
+	// Separator between the last two components, the default one of the locale by
+	// default. To separate all components in the same way, specify the same value as
+	// for Separator
+	Conjunction string
+	// Maximum number of shown components, all non-zero components are shown if it
+	// is not positive
+	MaxComponents int
+	// Whether the smallest shown component is rounded half up by the discarded ones,
+	// otherwise discarded components are truncated
+	Round bool
+}
+
+func (opts HumanizeOptions) normalize() HumanizeOptions {
+	if opts.Locale == nil {
+		opts.Locale = English()
+	}
+
+	if opts.Separator == "" {
+		opts.Separator = opts.Locale.Separator()
+	}
+
+	if opts.Conjunction == "" {
+		opts.Conjunction = opts.Locale.Conjunction()
+	}
+
+	return opts
+}
+
+// Returns a human-readable representation of the duration, e.g. 2 years, 3 months
+// and 10 days.
+//
+// Non-zero components are listed from years to nanoseconds with the names in
+// the form corresponding to the plural category of the value in the locale. Hours
+// are not converted to days, since a day is not always equal to 24 hours. Zero
+// duration is represented as 0 seconds.
+//
+// If the number of components is limited, then the largest non-zero components are
+// shown. When rounding, the smallest shown component is rounded only by components
+// with a fixed ratio to it: years by months, weeks by days and hours, minutes,
+// seconds, milliseconds and microseconds by the smaller of them. E.g. 1y7mo is
+// represented as 2 years, but 1mo20d as 1 month.
+func (whl Whilst) Humanize(opts HumanizeOptions) string {
+	opts = opts.normalize()
+
+	values := whl.humanValues(opts.MaxComponents, opts.Round)
+
+	output := make([]byte, 0, len(formatMaximum))
+
+	return string(appendHuman(output, values, whl.IsNegative(), opts))
+}
+
+func (whl Whilst) humanValues(maximum int, round bool) [unitsQuantity]uint64 {
+	nano := safe.Abs(whl.Nano)
+
+	values := splitHuman(whl, nano)
+
+	if maximum <= 0 {
+		return values
+	}
+
+	last := lastShown(values, maximum)
+
+	if last == UnitNanosecond {
+		return values
+	}
+
+	if round {
+		switch last {
+		case UnitYear:
+			values[UnitYear] += roundRatio(values[UnitMonth], monthsInYear)
+		case UnitWeek:
+			values[UnitWeek] += roundRatio(values[UnitDay], daysInWeek)
+		case UnitMonth, UnitDay:
+			// Ratio to the smaller components is not fixed
+		default:
+			dimension := humanDimensions[last]
+			values = splitHuman(whl, (nano+dimension/2)/dimension*dimension)
+		}
+	}
+
+	for id := last + 1; id < unitsQuantity; id++ {
+		values[id] = 0
+	}
+
+	return values
+}
+
+// Returns the unit of the last shown component.
+func lastShown(values [unitsQuantity]uint64, maximum int) Unit {
+	shown := 0
+
+	for id, value := range values {
+		if value == 0 {
+			continue
+		}
+
+		shown++
+
+		if shown == maximum {
+			return Unit(id)
+		}
+	}
+
+	return UnitNanosecond
+}
+
+// Returns the number of whole units, consisting of the specified number of smaller
+// units, in the remainder rounded half up.
+func roundRatio(remainder, ratio uint64) uint64 {
+	return (2*remainder + ratio) / (2 * ratio)
+}
+
+func splitHuman(whl Whilst, nano uint64) [unitsQuantity]uint64 {
+	hours, minutes, seconds, fraction := splitNano(nano)
+
+	values := [unitsQuantity]uint64{
+		UnitYear:        uint64(whl.Years),
+		UnitMonth:       uint64(whl.Months),
+		UnitWeek:        uint64(whl.Weeks),
+		UnitDay:         uint64(whl.Days),
+		UnitHour:        hours,
+		UnitMinute:      minutes,
+		UnitSecond:      seconds,
+		UnitMillisecond: fraction / consts.U64Millisecond,
+		UnitMicrosecond: fraction % consts.U64Millisecond / consts.U64Microsecond,
+		UnitNanosecond:  fraction % consts.U64Microsecond,
+	}
+
+	return values
+}
+
+func appendHuman(
+	output []byte,
+	values [unitsQuantity]uint64,
+	negative bool,
+	opts HumanizeOptions,
+) []byte {
+	quantity := 0
+
+	for _, value := range values {
+		if value != 0 {
+			quantity++
+		}
+	}
+
+	if quantity == 0 {
+		return appendHumanComponent(output, 0, UnitSecond, opts.Locale)
+	}
+
+	if negative {
+		output = append(output, charMinus)
+	}
+
+	written := 0
+
+	for id, value := range values {
+		if value == 0 {
+			continue
+		}
+
+		switch {
+		case written == 0:
+		case written == quantity-1:
+			output = append(output, opts.Conjunction...)
+		default:
+			output = append(output, opts.Separator...)
+		}
+
+		output = appendHumanComponent(output, value, Unit(id), opts.Locale)
+
+		written++
+	}
+
+	return output
+}
+
+func appendHumanComponent(output []byte, value uint64, unit Unit, locale Locale) []byte {
+	output = strconv.AppendUint(output, value, consts.DecimalBase)
+	output = append(output, ' ')
+	output = append(output, locale.UnitName(unit, locale.Plural(value))...)
+
+	return output
+}
+
+// Parses a long-form representation of the duration, e.g. 2 years, 3 months and
+// 10 days.
+//
+// Accepts the output of [Whilst.Humanize] with the same locale, separator and
+// conjunction, other options are ignored. Names of units are accepted in any of
+// the plural forms of the locale regardless of case. Values of hours and smaller
+// units may have a fractional part, limitations on values are the same as for
+// [Parse]. One of a signs - or + can be specified at a beginning of a string.
+//
+// In case of an error, [*ParseError] is returned.
+func ParseHumanized(input string, opts HumanizeOptions) (Whilst, error) {
+	whl := Whilst{}
+
+	if err := parseHumanized(input, opts.normalize(), &whl); err != nil {
+		return Whilst{}, err
+	}
+
+	return whl, nil
+}
+
+func parseHumanized(input string, opts HumanizeOptions, whl *Whilst) error {
+	trimmed := strings.TrimSpace(input)
+
+	if trimmed == "" {
+		return newFieldError(ErrInputEmpty, input, nil, nil, 0)
+	}
+
+	// Offsets of the fields are calculated relative to the input string before
+	// trimming
+	shift := len(input) - len(strings.TrimLeftFunc(input, unicode.IsSpace))
+
+	prs := &parser{}
+
+	switch trimmed[0] {
+	case charMinus:
+		prs.whl.Negative = true
+		trimmed = trimmed[1:]
+		shift++
+	case charPlus:
+		trimmed = trimmed[1:]
+		shift++
+	}
+
+	// Conjunction and separator are replaced by spaces of the same length to
+	// preserve offsets of the fields
+	spaced := trimmed
+	spaced = strings.ReplaceAll(spaced, opts.Conjunction, strings.Repeat(" ", len(opts.Conjunction)))
+	spaced = strings.ReplaceAll(spaced, opts.Separator, strings.Repeat(" ", len(opts.Separator)))
+
+	fields := strings.Fields(spaced)
+	offsets := fieldOffsets(spaced, fields)
+
+	for id := range offsets {
+		offsets[id] += shift
+	}
+
+	dividers := []string{
+		strings.TrimSpace(opts.Conjunction),
+		strings.TrimSpace(opts.Separator),
+	}
+
+	for id := len(fields) - 1; id >= 0; id-- {
+		if slices.Contains(dividers, fields[id]) {
+			fields = slices.Delete(fields, id, id+1)
+			offsets = slices.Delete(offsets, id, id+1)
+		}
+	}
+
+	if len(fields) == 0 {
+		return newFieldError(ErrNumberUnspecified, input, nil, nil, 0)
+	}
+
+	units := localeUnits(opts.Locale, true)
+
+	for id := 0; id < len(fields); id += 2 {
+		if err := prs.loadNumber(fields[id]); err != nil {
+			return newFieldError(err, input, fields, offsets, id)
+		}
+
+		if id+1 == len(fields) {
+			return newFieldError(ErrUnitUnspecified, input, fields, offsets, id)
+		}
+
+		unit, found := units[strings.ToLower(fields[id+1])]
+		if !found {
+			return newFieldError(ErrUnexpectedUnit, input, fields, offsets, id+1)
+		}
+
+		if err := prs.addUnit(shortUnits[unit]); err != nil {
+			return newFieldError(err, input, fields, offsets, id)
+		}
+	}
+
+	if prs.whl.IsZero() {
+		prs.whl.Negative = false
+	}
+
+	*whl = prs.whl
+
+	return nil
+}
+
+// Returns units of the locale by their names in all plural forms, optionally
+// converted to lower case.
+func localeUnits(locale Locale, lower bool) map[string]Unit {
+	units := make(map[string]Unit)
+
+	for unit := range unitsQuantity {
+		for category := PluralOther; category <= PluralMany; category++ {
+			name := locale.UnitName(unit, category)
+
+			if name == "" {
+				continue
+			}
+
+			if lower {
+				name = strings.ToLower(name)
+			}
+
+			units[name] = unit
+		}
+	}
+
+	return units
+}
@@ -0,0 +1,161 @@
+package whilst
+
+import (
+	"math"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestHumanize(t *testing.T) {
+	expected := []struct {
+		Whilst   Whilst
+		Options  HumanizeOptions
+		Expected string
+	}{
+		{
+			Whilst:   Whilst{Years: 2, Months: 3, Days: 10},
+			Expected: "2 years, 3 months and 10 days",
+		},
+		{
+			Whilst:   Whilst{Years: 1, Months: 1, Weeks: 1, Days: 1, Nano: time.Hour + time.Minute + time.Second},
+			Expected: "1 year, 1 month, 1 week, 1 day, 1 hour, 1 minute and 1 second",
+		},
+		{
+			Whilst:   Whilst{Nano: 1500 * time.Millisecond},
+			Expected: "1 second and 500 milliseconds",
+		},
+		{
+			Whilst:   Whilst{Nano: time.Millisecond + 2*time.Microsecond + 3*time.Nanosecond},
+			Expected: "1 millisecond, 2 microseconds and 3 nanoseconds",
+		},
+		{
+			Whilst:   Whilst{Days: 1, Negative: true},
+			Expected: "-1 day",
+		},
+		{
+			Whilst:   Whilst{Nano: -25 * time.Hour},
+			Expected: "-25 hours",
+		},
+		{
+			Whilst:   Whilst{},
+			Expected: "0 seconds",
+		},
+		{
+			Whilst:   Whilst{Negative: true},
+			Expected: "0 seconds",
+		},
+		{
+			Whilst:   Whilst{Years: 2, Months: 3, Days: 10},
+			Options:  HumanizeOptions{Separator: " ", Conjunction: " "},
+			Expected: "2 years 3 months 10 days",
+		},
+		{
+			Whilst:   Whilst{Years: 2, Months: 3, Days: 10},
+			Options:  HumanizeOptions{Conjunction: ", and "},
+			Expected: "2 years, 3 months, and 10 days",
+		},
+		{
+			Whilst:   Whilst{Years: 2, Months: 3, Days: 10},
+			Options:  HumanizeOptions{MaxComponents: 2},
+			Expected: "2 years and 3 months",
+		},
+		{
+			Whilst:   Whilst{Years: 2, Days: 10, Nano: time.Hour},
+			Options:  HumanizeOptions{MaxComponents: 2},
+			Expected: "2 years and 10 days",
+		},
+		{
+			Whilst:   Whilst{Years: 2, Months: 3},
+			Options:  HumanizeOptions{MaxComponents: 3},
+			Expected: "2 years and 3 months",
+		},
+		{
+			Whilst:   Whilst{Years: 2, Months: 3},
+			Options:  HumanizeOptions{MaxComponents: -1},
+			Expected: "2 years and 3 months",
+		},
+		{
+			Whilst:   Whilst{Years: 1, Months: 7},
+			Options:  HumanizeOptions{MaxComponents: 1},
+			Expected: "1 year",
+		},
+		{
+			Whilst:   Whilst{Years: 1, Months: 7},
+			Options:  HumanizeOptions{MaxComponents: 1, Round: true},
+			Expected: "2 years",
+		},
+		{
+			Whilst:   Whilst{Years: 1, Months: 5, Days: 30},
+			Options:  HumanizeOptions{MaxComponents: 1, Round: true},
+			Expected: "1 year",
+		},
+		{
+			Whilst:   Whilst{Years: 1, Months: 6},
+			Options:  HumanizeOptions{MaxComponents: 1, Round: true},
+			Expected: "2 years",
+		},
+		{
+			Whilst:   Whilst{Months: 1, Days: 20},
+			Options:  HumanizeOptions{MaxComponents: 1, Round: true},
+			Expected: "1 month",
+		},
+		{
+			Whilst:   Whilst{Weeks: 1, Days: 4},
+			Options:  HumanizeOptions{MaxComponents: 1, Round: true},
+			Expected: "2 weeks",
+		},
+		{
+			Whilst:   Whilst{Weeks: 1, Days: 3},
+			Options:  HumanizeOptions{MaxComponents: 1, Round: true},
+			Expected: "1 week",
+		},
+		{
+			Whilst:   Whilst{Days: 1, Nano: 23 * time.Hour},
+			Options:  HumanizeOptions{MaxComponents: 1, Round: true},
+			Expected: "1 day",
+		},
+		{
+			Whilst:   Whilst{Nano: time.Hour + 59*time.Minute + 40*time.Second},
+			Options:  HumanizeOptions{MaxComponents: 2, Round: true},
+			Expected: "2 hours",
+		},
+		{
+			Whilst:   Whilst{Nano: time.Hour + 29*time.Minute + 59*time.Second},
+			Options:  HumanizeOptions{MaxComponents: 1, Round: true},
+			Expected: "1 hour",
+		},
+		{
+			Whilst:   Whilst{Nano: -time.Hour - 30*time.Minute},
+			Options:  HumanizeOptions{MaxComponents: 1, Round: true},
+			Expected: "-2 hours",
+		},
+		{
+			Whilst:   Whilst{Nano: 1500 * time.Millisecond},
+			Options:  HumanizeOptions{MaxComponents: 1, Round: true},
+			Expected: "2 seconds",
+		},
+		{
+			Whilst:   Whilst{Nano: math.MinInt64},
+			Options:  HumanizeOptions{MaxComponents: 1, Round: true},
+			Expected: "-2562048 hours",
+		},
+		{
+			Whilst:   Whilst{Years: math.MaxUint16, Months: 11},
+			Options:  HumanizeOptions{MaxComponents: 1, Round: true},
+			Expected: "65536 years",
+		},
+	}
+
+	for _, item := range expected {
+		require.Equal(
+			t,
+			item.Expected,
+			item.Whilst.Humanize(item.Options),
+			"whilst: %v, options: %+v",
+			item.Whilst,
+			item.Options,
+		)
+	}
+}
@@ -4,6 +4,9 @@
 package credible
 
 import (
+	"math"
+	"math/bits"
+
 	"github.com/akramarenkov/whilst/internal/consts"
 
 	"github.com/akramarenkov/intspec"
@@ -166,3 +169,252 @@ func MulByHour(number uint64) (uint64, error) {
 
 	return number * consts.U64Hour, nil
 }
+
+// Multiplies an integer of uint16 type by an integer of uint64 type and detects
+// whether an overflow has occurred or not.
+func MulU16ByU64(first uint16, second uint64) (uint16, error) {
+	if first == 0 {
+		return 0, nil
+	}
+
+	if second > intspec.MaxUint16/uint64(first) {
+		return 0, safe.ErrOverflow
+	}
+
+	return first * uint16(second), nil
+}
+
+// Maximum number of digits of a fraction, above which the product of the fraction
+// and an integer of uint64 type is always less than one.
+const maxFractionDigits = 38
+
+// Powers of ten, calculated in the same way as time.ParseDuration does, i.e. by
+// successive multiplication by ten with rounding of float64 arithmetic.
+//
+//nolint:gochecknoglobals // To increase performance
+var scales = func() [maxFractionDigits + 1]binary {
+	var scales [maxFractionDigits + 1]binary
+
+	scales[0] = newBinary(1)
+
+	for id := 1; id < len(scales); id++ {
+		scales[id] = scales[id-1].mul(newBinary(consts.DecimalBase))
+	}
+
+	return scales
+}()
+
+// Multiplies a decimal fraction, specified by a numerator and a number of digits
+// after a decimal point, by an integer of uint64 type and returns an integer part
+// of the product.
+//
+// Calculation reproduces the result of the float64 expression used by
+// time.ParseDuration, numerator * (factor / 10^digits), bit-for-bit, including
+// rounding to nearest even of each operation, but is performed with integers. It is
+// assumed that the numerator is less than 10^digits.
+func MulFraction(numerator uint64, digits int, factor uint64) uint64 {
+	if numerator == 0 || digits > maxFractionDigits {
+		return 0
+	}
+
+	if product, exact := mulFractionExact(numerator, digits, factor); exact {
+		return product
+	}
+
+	return newBinary(numerator).mul(newBinary(factor).quo(scales[digits])).integer()
+}
+
+// Maximum value of an integer of uint64 type that is exactly representable by
+// float64 together with all smaller integers.
+const maxExactInteger = 1 << mantissaBits
+
+// Maximum number of digits of a fraction for which a non-zero factor exactly
+// representable by float64 can be divisible by the scale.
+const maxExactDigits = 15
+
+// Divisors by powers of five, which are used to divide exactly without division
+// instructions: a number is divisible by 5^n if and only if its product with
+// the multiplicative inverse of 5^n modulo 2^64 does not exceed the limit, and then
+// the product is equal to the quotient.
+//
+//nolint:gochecknoglobals // To increase performance
+var divisorsBy5 = func() [maxExactDigits + 1]struct{ inverse, limit uint64 } {
+	var divisors [maxExactDigits + 1]struct{ inverse, limit uint64 }
+
+	power := uint64(1)
+
+	for id := range divisors {
+		// Newton's iteration doubles the number of correct low bits of the inverse,
+		// starting from three for any odd number
+		inverse := power
+
+		for range 5 {
+			inverse *= 2 - power*inverse
+		}
+
+		divisors[id].inverse = inverse
+		divisors[id].limit = math.MaxUint64 / power
+
+		power *= 5
+	}
+
+	return divisors
+}()
+
+// Calculates the product of MulFraction using integers only, if none of operations
+// of the float64 expression is rounded, i.e. the factor is divisible by the scale
+// and all operands and results are exactly representable by float64. Reports
+// whether the calculation has been performed.
+func mulFractionExact(numerator uint64, digits int, factor uint64) (uint64, bool) {
+	if digits > maxExactDigits || factor > maxExactInteger || numerator > maxExactInteger {
+		return 0, false
+	}
+
+	// Division by 10^digits is performed as a shift by digits followed by division
+	// by 5^digits
+	if bits.TrailingZeros64(factor) < digits {
+		return 0, false
+	}
+
+	quotient := (factor >> digits) * divisorsBy5[digits].inverse
+
+	if quotient > divisorsBy5[digits].limit {
+		return 0, false
+	}
+
+	hi, product := bits.Mul64(numerator, quotient)
+	if hi != 0 || product > maxExactInteger {
+		return 0, false
+	}
+
+	return product, true
+}
+
+// Binary floating-point number with the same precision of the mantissa as float64
+// has, its value is equal to mantissa * 2^exponent.
+//
+// Mantissa of a non-zero number is normalized, i.e. its most significant bit is
+// the 53rd one. Exponent is not limited, since numbers used in calculations are far
+// from the range limits of float64.
+type binary struct {
+	mantissa uint64
+	exponent int
+}
+
+// Number of bits of the mantissa of float64, including the implicit one.
+const mantissaBits = 53
+
+// Creates a binary number rounded from an integer of uint64 type, just like
+// conversion to float64 does.
+func newBinary(number uint64) binary {
+	return roundBinary(0, number, 0)
+}
+
+// Rounds a 128-bit integer multiplied by 2^exponent to the nearest binary number,
+// ties are rounded to even.
+func roundBinary(hi, lo uint64, exponent int) binary {
+	length := bits.Len64(lo)
+
+	if hi != 0 {
+		length = bits.Len64(hi) + bits.UintSize
+	}
+
+	if length == 0 {
+		return binary{}
+	}
+
+	if length <= mantissaBits {
+		shift := mantissaBits - length
+
+		return binary{mantissa: lo << shift, exponent: exponent - shift}
+	}
+
+	shift := length - mantissaBits
+
+	var mantissa uint64
+
+	if shift >= bits.UintSize {
+		mantissa = hi >> (shift - bits.UintSize)
+	} else {
+		mantissa = lo>>shift | hi<<(bits.UintSize-shift)
+	}
+
+	if bitOf(hi, lo, shift-1) && (isLowNonZero(hi, lo, shift-1) || mantissa&1 == 1) {
+		mantissa++
+
+		if mantissa == 1<<mantissaBits {
+			mantissa >>= 1
+			shift++
+		}
+	}
+
+	return binary{mantissa: mantissa, exponent: exponent + shift}
+}
+
+// Reports whether the bit at the specified position of a 128-bit integer is set.
+func bitOf(hi, lo uint64, position int) bool {
+	if position >= bits.UintSize {
+		return hi>>(position-bits.UintSize)&1 == 1
+	}
+
+	return lo>>position&1 == 1
+}
+
+// Reports whether any of the bits below the specified position of a 128-bit integer
+// is set.
+func isLowNonZero(hi, lo uint64, position int) bool {
+	if position > bits.UintSize {
+		return lo != 0 || hi<<(2*bits.UintSize-position) != 0
+	}
+
+	if position == bits.UintSize {
+		return lo != 0
+	}
+
+	return lo<<(bits.UintSize-position) != 0
+}
+
+// Returns the product of the numbers rounded to the nearest binary number.
+func (bnr binary) mul(other binary) binary {
+	hi, lo := bits.Mul64(bnr.mantissa, other.mantissa)
+
+	return roundBinary(hi, lo, bnr.exponent+other.exponent)
+}
+
+// Returns the quotient of the numbers rounded to the nearest binary number. It is
+// assumed that the divisor is not zero.
+func (bnr binary) quo(divisor binary) binary {
+	if bnr.mantissa == 0 {
+		return binary{}
+	}
+
+	// Dividend is shifted so that the quotient has more bits than the mantissa and
+	// the high half of the dividend is less than the normalized divisor
+	const shift = bits.UintSize - 1
+
+	quotient, remainder := bits.Div64(bnr.mantissa>>1, bnr.mantissa<<shift, divisor.mantissa)
+
+	// Quotient is supplemented with the bit indicating an inexact division, which is
+	// lower than the rounding bit
+	sticky := uint64(0)
+
+	if remainder != 0 {
+		sticky = 1
+	}
+
+	return roundBinary(quotient>>shift, quotient<<1|sticky, bnr.exponent-divisor.exponent-shift-1)
+}
+
+// Returns the integer part of the number, it is assumed that it fits into the uint64
+// type.
+func (bnr binary) integer() uint64 {
+	if bnr.exponent >= 0 {
+		return bnr.mantissa << bnr.exponent
+	}
+
+	if -bnr.exponent >= bits.UintSize {
+		return 0
+	}
+
+	return bnr.mantissa >> -bnr.exponent
+}
@@ -2,6 +2,7 @@ package credible
 
 import (
 	"math"
+	"math/big"
 	"testing"
 
 	"github.com/akramarenkov/whilst/internal/consts"
@@ -144,3 +145,145 @@ func TestMulByHour(t *testing.T) {
 	require.Error(t, err)
 	require.Equal(t, uint64(0), sum)
 }
+
+func TestMulU16ByU64(t *testing.T) {
+	product, err := MulU16ByU64(0, math.MaxUint64)
+	require.NoError(t, err)
+	require.Equal(t, uint16(0), product)
+
+	product, err = MulU16ByU64(math.MaxUint16, 1)
+	require.NoError(t, err)
+	require.Equal(t, uint16(math.MaxUint16), product)
+
+	product, err = MulU16ByU64(1, math.MaxUint16)
+	require.NoError(t, err)
+	require.Equal(t, uint16(math.MaxUint16), product)
+
+	product, err = MulU16ByU64(255, 257)
+	require.NoError(t, err)
+	require.Equal(t, uint16(math.MaxUint16), product)
+
+	product, err = MulU16ByU64(256, 256)
+	require.Error(t, err)
+	require.Equal(t, uint16(0), product)
+
+	product, err = MulU16ByU64(2, math.MaxUint16)
+	require.Error(t, err)
+	require.Equal(t, uint16(0), product)
+
+	product, err = MulU16ByU64(1, math.MaxUint64)
+	require.Error(t, err)
+	require.Equal(t, uint16(0), product)
+}
+
+// Converts a fraction in the same way as time.ParseDuration does.
+func parseDurationFraction(numerator uint64, digits int, factor uint64) uint64 {
+	scale := 1.0
+
+	for range digits {
+		scale *= consts.DecimalBase
+	}
+
+	return uint64(float64(numerator) * (float64(factor) / scale))
+}
+
+func TestMulFraction(t *testing.T) {
+	require.Equal(t, uint64(0), MulFraction(0, 0, consts.U64Hour))
+	require.Equal(t, uint64(1800000000000), MulFraction(5, 1, consts.U64Hour))
+	require.Equal(t, uint64(3600), MulFraction(1, 9, consts.U64Hour))
+	require.Equal(t, uint64(900000000008), MulFraction(25000000000250, 14, consts.U64Hour))
+	require.Equal(t, uint64(3476544825345), MulFraction(9657068959291666579, 19, consts.U64Hour))
+	require.Equal(t, uint64(1000000000), MulFraction(9999999999999999999, 19, consts.U64Second))
+	require.Equal(t, uint64(36), MulFraction(9999999999999999999, 30, consts.U64Hour))
+	require.Equal(t, uint64(0), MulFraction(9999999999999999999, 32, consts.U64Hour))
+	require.Equal(t, uint64(0), MulFraction(1, 1000, consts.U64Hour))
+	require.Equal(t, uint64(0), MulFraction(0, 1000, consts.U64Hour))
+
+	inputs := []struct {
+		Numerator uint64
+		Digits    int
+		Factor    uint64
+	}{
+		{25000000000250, 14, consts.U64Hour},
+		{9657068959291666579, 19, consts.U64Hour},
+		{9999999999999999999, 19, consts.U64Second},
+		{9999999999999999999, 30, consts.U64Hour},
+		{1 << 63, 19, 1<<63 - 1},
+		{1<<53 + 1, 16, 1<<53 + 1},
+		{123456789, 38, math.MaxInt64},
+		{999999999999999, 15, 9000000000000000},
+		{999999999999999, 15, 1 << 53},
+		{9999999999999999, 16, 9000000000000000},
+		{99999999999999999, 17, 1<<53 + 1},
+		{9999999999999999999, 19, 1<<53 - 5},
+	}
+
+	for _, item := range inputs {
+		require.Equal(
+			t,
+			parseDurationFraction(item.Numerator, item.Digits, item.Factor),
+			MulFraction(item.Numerator, item.Digits, item.Factor),
+			"numerator: %v, digits: %v, factor: %v",
+			item.Numerator,
+			item.Digits,
+			item.Factor,
+		)
+	}
+}
+
+func TestMulFractionExact(t *testing.T) {
+	for digits := range maxExactDigits + 2 {
+		scale := uint64(math.Pow10(digits))
+
+		factors := []uint64{0, 1, maxExactInteger, maxExactInteger - 1}
+		numerators := []uint64{1, scale - 1, scale / 3, 123456789 % scale}
+
+		for multiplier := uint64(1); multiplier*scale <= maxExactInteger && multiplier <= 100; multiplier++ {
+			factors = append(factors, multiplier*scale, multiplier*scale+1, multiplier*scale-1)
+		}
+
+		for _, factor := range factors {
+			for _, numerator := range numerators {
+				require.Equal(
+					t,
+					parseDurationFraction(numerator, digits, factor),
+					MulFraction(numerator, digits, factor),
+					"numerator: %v, digits: %v, factor: %v",
+					numerator,
+					digits,
+					factor,
+				)
+			}
+		}
+	}
+}
+
+func FuzzMulFraction(f *testing.F) {
+	f.Add(uint64(25000000000250), 14, consts.U64Hour)
+	f.Add(uint64(9657068959291666579), 19, consts.U64Hour)
+	f.Add(uint64(math.MaxUint64), 40, uint64(9999999999999999999))
+	f.Add(uint64(1), 320, consts.U64Hour)
+	f.Add(uint64(999999999999999), 15, uint64(9000000000000000))
+
+	f.Fuzz(
+		func(t *testing.T, numerator uint64, digits int, factor uint64) {
+			const maxDigits = 400
+
+			if digits < 0 || digits > maxDigits || factor > math.MaxInt64 {
+				return
+			}
+
+			scale := new(big.Int).Exp(big.NewInt(consts.DecimalBase), big.NewInt(int64(digits)), nil)
+
+			if new(big.Int).SetUint64(numerator).Cmp(scale) >= 0 {
+				return
+			}
+
+			require.Equal(
+				t,
+				parseDurationFraction(numerator, digits, factor),
+				MulFraction(numerator, digits, factor),
+			)
+		},
+	)
+}
@@ -0,0 +1,346 @@
+package whilst
+
+import (
+	"errors"
+	"iter"
+	"strings"
+	"time"
+)
+
+// Half-open time interval [start, end) anchored at the start time and specified by
+// the duration.
+//
+// Since the length of the duration depends on the time relative to which it is
+// shifted, the end of the interval is calculated as [Whilst.When] of the start time,
+// e.g. an interval of 1mo starting on January 31 of a non-leap year ends on March 3.
+//
+// Zero value of the interval is an empty interval starting at the zero time.
+type Interval struct {
+	start  time.Time
+	period Whilst
+}
+
+// Creates an interval starting at the time start and lasting for the duration.
+//
+// Panics if the duration of the interval cannot be represented, see
+// [NewIntervalChecked].
+func NewInterval(start time.Time, whl Whilst) Interval {
+	inv, err := NewIntervalChecked(start, whl)
+	if err != nil {
+		panic(err)
+	}
+
+	return inv
+}
+
+// Creates an interval starting at the time start and lasting for the duration.
+//
+// If the duration is negative, the interval ends at the time start and begins at
+// the time start shifted by the duration, while the duration of the interval is
+// recalculated as [BetweenChecked] them, so an error is returned if it cannot be
+// represented, e.g. for -65535y65535mo.
+func NewIntervalChecked(start time.Time, whl Whilst) (Interval, error) {
+	if !whl.IsNegative() {
+		// Negative zero is turned into zero
+		whl.Negative = false
+
+		return Interval{start: start, period: whl}, nil
+	}
+
+	return boundInterval(whl.When(start), start)
+}
+
+// Creates an interval between two times, the duration of the interval is calculated
+// using [Between]. The order of the times does not matter.
+//
+// Panics if the duration cannot be represented, see [IntervalBetweenChecked].
+func IntervalBetween(from, to time.Time) Interval {
+	inv, err := IntervalBetweenChecked(from, to)
+	if err != nil {
+		panic(err)
+	}
+
+	return inv
+}
+
+// Creates an interval between two times, the duration of the interval is calculated
+// using [BetweenChecked]. The order of the times does not matter.
+//
+// If the duration cannot be represented, then an error is returned.
+func IntervalBetweenChecked(from, to time.Time) (Interval, error) {
+	if to.Before(from) {
+		return boundInterval(to, from)
+	}
+
+	return boundInterval(from, to)
+}
+
+// Returns the start of the interval, it belongs to the interval unless the interval
+// is empty.
+func (inv Interval) Start() time.Time {
+	return inv.start
+}
+
+// Returns the end of the interval, it does not belong to the interval.
+func (inv Interval) End() time.Time {
+	return inv.period.When(inv.start)
+}
+
+// Returns the duration of the interval.
+func (inv Interval) Period() Whilst {
+	return inv.period
+}
+
+// Reports whether the interval contains no time.
+func (inv Interval) IsEmpty() bool {
+	return !inv.End().After(inv.start)
+}
+
+// Reports whether the time belongs to the interval.
+func (inv Interval) Contains(moment time.Time) bool {
+	return !moment.Before(inv.start) && moment.Before(inv.End())
+}
+
+// Reports whether the intervals have at least one common time.
+func (inv Interval) Overlaps(other Interval) bool {
+	if inv.IsEmpty() || other.IsEmpty() {
+		return false
+	}
+
+	return inv.start.Before(other.End()) && other.start.Before(inv.End())
+}
+
+// Returns the intersection of the intervals and reports whether the intervals
+// overlap.
+//
+// Panics if the duration of the intersection cannot be represented, see
+// [Interval.IntersectChecked].
+func (inv Interval) Intersect(other Interval) (Interval, bool) {
+	intersection, overlap, err := inv.IntersectChecked(other)
+	if err != nil {
+		panic(err)
+	}
+
+	return intersection, overlap
+}
+
+// Returns the intersection of the intervals and reports whether the intervals
+// overlap.
+//
+// The duration of the intersection is calculated using [BetweenChecked] in
+// the location of its start, so an error is returned if it cannot be represented,
+// e.g. for the intersection of an interval of 65535y65535mo with itself.
+func (inv Interval) IntersectChecked(other Interval) (Interval, bool, error) {
+	if !inv.Overlaps(other) {
+		return Interval{}, false, nil
+	}
+
+	intersection, err := boundInterval(later(inv.start, other.start), earlier(inv.End(), other.End()))
+	if err != nil {
+		return Interval{}, false, err
+	}
+
+	return intersection, true, nil
+}
+
+// Returns the union of the intervals and reports whether it is a single interval,
+// i.e. the intervals overlap or adjoin. Empty intervals are ignored.
+//
+// Panics if the duration of the union cannot be represented, see
+// [Interval.UnionChecked].
+func (inv Interval) Union(other Interval) (Interval, bool) {
+	union, single, err := inv.UnionChecked(other)
+	if err != nil {
+		panic(err)
+	}
+
+	return union, single
+}
+
+// Returns the union of the intervals and reports whether it is a single interval,
+// i.e. the intervals overlap or adjoin. Empty intervals are ignored.
+//
+// The duration of the union is calculated using [BetweenChecked] in the location of
+// its start, so an error is returned if it cannot be represented.
+func (inv Interval) UnionChecked(other Interval) (Interval, bool, error) {
+	switch {
+	case other.IsEmpty():
+		return inv, true, nil
+	case inv.IsEmpty():
+		return other, true, nil
+	}
+
+	if inv.start.After(other.End()) || other.start.After(inv.End()) {
+		return Interval{}, false, nil
+	}
+
+	union, err := boundInterval(earlier(inv.start, other.start), later(inv.End(), other.End()))
+	if err != nil {
+		return Interval{}, false, err
+	}
+
+	return union, true, nil
+}
+
+// Returns a sequence of consecutive sub-intervals into which the interval is split
+// by the step.
+//
+// The n-th sub-interval starts at the start of the interval shifted by the step
+// multiplied by n, just like [Every] does, so the sub-intervals do not drift, e.g.
+// 1mo starting from January 31 is split by 1mo into intervals starting on
+// January 31, March 3, March 31 and so on. The last sub-interval is truncated by
+// the end of the interval. The duration of each sub-interval is calculated using
+// [Between].
+//
+// Panics if the step is zero or negative. Also panics during the iteration if
+// the duration of a sub-interval cannot be represented, see [BetweenChecked].
+func (inv Interval) Split(step Whilst) iter.Seq[Interval] {
+	if step.IsZero() || step.IsNegative() {
+		panic(ErrNotPositive)
+	}
+
+	sgn := step.signed()
+	end := inv.End()
+
+	seq := func(yield func(Interval) bool) {
+		current := inv.start
+
+		for number := int64(1); current.Before(end); number++ {
+			next, err := sgn.occurrence(inv.start, number)
+			if err != nil || next.After(end) {
+				next = end
+			}
+
+			sub, err := boundInterval(current, next)
+			if err != nil {
+				panic(err)
+			}
+
+			if !yield(sub) {
+				return
+			}
+
+			current = next
+		}
+	}
+
+	return seq
+}
+
+// Returns an ISO 8601 representation of the interval in the form of start time and
+// duration separated by a slash, e.g. 2023-01-31T00:00:00Z/P1M.
+//
+// The start time is represented in the [time.RFC3339Nano] format and the duration as
+// [Whilst.ISO8601] does.
+func (inv Interval) String() string {
+	return inv.start.Format(time.RFC3339Nano) + string(charSlash) + inv.period.ISO8601()
+}
+
+// Returns an ISO 8601 representation of the interval as a byte slice.
+//
+// Implements the [encoding.TextMarshaler] interface.
+func (inv Interval) MarshalText() ([]byte, error) {
+	return []byte(inv.String()), nil
+}
+
+// Parses an ISO 8601 representation of the interval from the byte slice.
+//
+// Implements the [encoding.TextUnmarshaler] interface. Accepts the same input as
+// [ParseInterval]. In case of an error, the interval remains unchanged.
+func (inv *Interval) UnmarshalText(input []byte) error {
+	parsed, err := ParseInterval(string(input))
+	if err != nil {
+		return err
+	}
+
+	*inv = parsed
+
+	return nil
+}
+
+// Parses an ISO 8601 representation of the interval in the form of start time and
+// duration separated by a slash.
+//
+// The start time must be in the [time.RFC3339] format, optionally with fractional
+// seconds, and the duration is parsed by [ParseISO8601]. A negative duration is
+// handled as [NewIntervalChecked] does.
+//
+// In case of an error, [*ParseError] is returned.
+//
+// Example of strings:
+//   - 2023-01-31T00:00:00Z/P1M
+//   - 2023-03-25T12:00:00+01:00/P1DT12H
+func ParseInterval(input string) (Interval, error) {
+	start, duration, found := strings.Cut(input, string(charSlash))
+	if !found {
+		parseErr := &ParseError{
+			Input:  input,
+			Offset: len(input),
+			Err:    ErrSlashUnspecified,
+		}
+
+		return Interval{}, parseErr
+	}
+
+	anchor, err := time.Parse(time.RFC3339Nano, start)
+	if err != nil {
+		parseErr := &ParseError{
+			Input: input,
+			Token: start,
+			Err:   err,
+		}
+
+		return Interval{}, parseErr
+	}
+
+	whl, err := ParseISO8601(duration)
+	if err != nil {
+		if parseErr := (*ParseError)(nil); errors.As(err, &parseErr) {
+			parseErr.Input = input
+			parseErr.Offset += len(start) + 1
+		}
+
+		return Interval{}, err
+	}
+
+	inv, err := NewIntervalChecked(anchor, whl)
+	if err != nil {
+		parseErr := &ParseError{
+			Input:  input,
+			Offset: len(start) + 1,
+			Token:  duration,
+			Err:    err,
+		}
+
+		return Interval{}, parseErr
+	}
+
+	return inv, nil
+}
+
+// Creates an interval between two times, the time to must not be before the time
+// from.
+func boundInterval(from, to time.Time) (Interval, error) {
+	whl, err := BetweenChecked(from, to)
+	if err != nil {
+		return Interval{}, err
+	}
+
+	return Interval{start: from, period: whl}, nil
+}
+
+func earlier(first, second time.Time) time.Time {
+	if second.Before(first) {
+		return second
+	}
+
+	return first
+}
+
+func later(first, second time.Time) time.Time {
+	if second.After(first) {
+		return second
+	}
+
+	return first
+}
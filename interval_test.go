@@ -0,0 +1,330 @@
+package whilst
+
+import (
+	"encoding/json"
+	"math"
+	"slices"
+	"testing"
+	"time"
+
+	"github.com/akramarenkov/safe"
+	"github.com/stretchr/testify/require"
+)
+
+func TestInterval(t *testing.T) {
+	start := time.Date(2023, time.January, 31, 0, 0, 0, 0, time.UTC)
+
+	inv := NewInterval(start, Whilst{Months: 1})
+
+	require.Equal(t, start, inv.Start())
+	require.Equal(t, time.Date(2023, time.March, 3, 0, 0, 0, 0, time.UTC), inv.End())
+	require.Equal(t, Whilst{Months: 1}, inv.Period())
+	require.False(t, inv.IsEmpty())
+
+	require.False(t, inv.Contains(start.Add(-1)))
+	require.True(t, inv.Contains(start))
+	require.True(t, inv.Contains(inv.End().Add(-1)))
+	require.False(t, inv.Contains(inv.End()))
+
+	negative := NewInterval(time.Date(2023, time.March, 31, 0, 0, 0, 0, time.UTC), Whilst{Months: 1, Negative: true})
+
+	require.Equal(t, time.Date(2023, time.March, 3, 0, 0, 0, 0, time.UTC), negative.Start())
+	require.Equal(t, time.Date(2023, time.March, 31, 0, 0, 0, 0, time.UTC), negative.End())
+	require.Equal(t, Whilst{Days: 28}, negative.Period())
+
+	require.Equal(t, inv, IntervalBetween(start, inv.End()))
+	require.Equal(t, inv, IntervalBetween(inv.End(), start))
+
+	empty := NewInterval(start, Whilst{Negative: true})
+
+	require.Equal(t, Whilst{}, empty.Period())
+	require.True(t, empty.IsEmpty())
+	require.False(t, empty.Contains(start))
+	require.True(t, Interval{}.IsEmpty())
+}
+
+func TestIntervalLocation(t *testing.T) {
+	location := testLocation(t)
+
+	// Transition to the summer time occurs on March 26, 2023
+	inv := NewInterval(time.Date(2023, time.March, 25, 12, 0, 0, 0, location), Whilst{Days: 1, Nano: 12 * time.Hour})
+
+	require.Equal(t, time.Date(2023, time.March, 27, 0, 0, 0, 0, location), inv.End())
+	require.Equal(t, 35*time.Hour, inv.End().Sub(inv.Start()))
+}
+
+func TestIntervalOverlaps(t *testing.T) {
+	january := NewInterval(time.Date(2023, time.January, 1, 0, 0, 0, 0, time.UTC), Whilst{Months: 1})
+	february := NewInterval(time.Date(2023, time.February, 1, 0, 0, 0, 0, time.UTC), Whilst{Months: 1})
+	middle := NewInterval(time.Date(2023, time.January, 15, 0, 0, 0, 0, time.UTC), Whilst{Months: 1})
+	march := NewInterval(time.Date(2023, time.March, 1, 0, 0, 0, 0, time.UTC), Whilst{Days: 1})
+	empty := NewInterval(time.Date(2023, time.January, 15, 0, 0, 0, 0, time.UTC), Whilst{})
+
+	require.True(t, january.Overlaps(middle))
+	require.True(t, middle.Overlaps(january))
+	require.False(t, january.Overlaps(february))
+	require.False(t, february.Overlaps(january))
+	require.False(t, january.Overlaps(empty))
+
+	intersection, overlap := january.Intersect(middle)
+	require.True(t, overlap)
+	require.Equal(t, NewInterval(middle.Start(), Whilst{Days: 17}), intersection)
+
+	intersection, overlap = middle.Intersect(january)
+	require.True(t, overlap)
+	require.Equal(t, NewInterval(middle.Start(), Whilst{Days: 17}), intersection)
+
+	_, overlap = january.Intersect(february)
+	require.False(t, overlap)
+
+	union, single := january.Union(february)
+	require.True(t, single)
+	require.Equal(t, NewInterval(january.Start(), Whilst{Months: 2}), union)
+
+	union, single = middle.Union(january)
+	require.True(t, single)
+	require.Equal(t, IntervalBetween(january.Start(), middle.End()), union)
+
+	union, single = january.Union(empty)
+	require.True(t, single)
+	require.Equal(t, january, union)
+
+	union, single = empty.Union(january)
+	require.True(t, single)
+	require.Equal(t, january, union)
+
+	_, single = january.Union(march)
+	require.False(t, single)
+}
+
+func TestIntervalSplit(t *testing.T) {
+	start := time.Date(2023, time.January, 31, 0, 0, 0, 0, time.UTC)
+
+	expected := []Interval{
+		NewInterval(start, Whilst{Months: 1}),
+		NewInterval(time.Date(2023, time.March, 3, 0, 0, 0, 0, time.UTC), Whilst{Days: 28}),
+		NewInterval(time.Date(2023, time.March, 31, 0, 0, 0, 0, time.UTC), Whilst{Months: 1}),
+	}
+
+	inv := NewInterval(start, Whilst{Months: 3})
+
+	require.Equal(t, expected, slices.Collect(inv.Split(Whilst{Months: 1})))
+
+	expected = []Interval{
+		NewInterval(start, Whilst{Days: 7}),
+		NewInterval(start.AddDate(0, 0, 7), Whilst{Days: 3}),
+	}
+
+	inv = NewInterval(start, Whilst{Days: 10})
+
+	require.Equal(t, expected, slices.Collect(inv.Split(Whilst{Weeks: 1})))
+	require.Equal(t, []Interval{inv}, slices.Collect(inv.Split(Whilst{Years: 1})))
+	require.Empty(t, slices.Collect(Interval{}.Split(Whilst{Days: 1})))
+
+	for sub := range inv.Split(Whilst{Days: 1}) {
+		require.Equal(t, NewInterval(start, Whilst{Days: 1}), sub)
+		break
+	}
+
+	require.PanicsWithValue(t, ErrNotPositive, func() { inv.Split(Whilst{}) })
+	require.PanicsWithValue(t, ErrNotPositive, func() { inv.Split(Whilst{Days: 1, Negative: true}) })
+}
+
+func TestIntervalString(t *testing.T) {
+	location := testLocation(t)
+
+	expected := []struct {
+		Interval Interval
+		String   string
+	}{
+		{
+			Interval: NewInterval(time.Date(2023, time.January, 31, 0, 0, 0, 0, time.UTC), Whilst{Months: 1}),
+			String:   "2023-01-31T00:00:00Z/P1M",
+		},
+		{
+			Interval: NewInterval(time.Date(2023, time.March, 25, 12, 0, 0, 0, location), Whilst{Days: 1, Nano: 12 * time.Hour}),
+			String:   "2023-03-25T12:00:00+01:00/P1DT12H",
+		},
+		{
+			Interval: NewInterval(time.Date(2023, time.January, 1, 0, 0, 0, 500, time.UTC), Whilst{}),
+			String:   "2023-01-01T00:00:00.0000005Z/PT0S",
+		},
+	}
+
+	for _, item := range expected {
+		require.Equal(t, item.String, item.Interval.String())
+
+		parsed, err := ParseInterval(item.String)
+		require.NoError(t, err)
+		require.True(t, item.Interval.Start().Equal(parsed.Start()))
+		require.Equal(t, item.Interval.Period(), parsed.Period())
+		require.Equal(t, item.String, parsed.String())
+	}
+
+	parsed, err := ParseInterval("2023-03-31T00:00:00Z/-P1M")
+	require.NoError(t, err)
+	require.Equal(t, "2023-03-03T00:00:00Z/P28D", parsed.String())
+}
+
+func TestParseIntervalError(t *testing.T) {
+	var parseErr *ParseError
+
+	_, err := ParseInterval("2023-01-31T00:00:00Z")
+	require.ErrorIs(t, err, ErrSlashUnspecified)
+	require.ErrorAs(t, err, &parseErr)
+	require.Equal(t, len("2023-01-31T00:00:00Z"), parseErr.Offset)
+
+	_, err = ParseInterval("2023-01-31/P1M")
+
+	var timeErr *time.ParseError
+
+	require.ErrorAs(t, err, &timeErr)
+	require.ErrorAs(t, err, &parseErr)
+	require.Equal(t, 0, parseErr.Offset)
+	require.Equal(t, "2023-01-31", parseErr.Token)
+
+	_, err = ParseInterval("2023-01-31T00:00:00Z/1M")
+	require.ErrorIs(t, err, ErrPeriodUnspecified)
+	require.ErrorAs(t, err, &parseErr)
+	require.Equal(t, "2023-01-31T00:00:00Z/1M", parseErr.Input)
+	require.Equal(t, len("2023-01-31T00:00:00Z/"), parseErr.Offset)
+
+	_, err = ParseInterval("2023-01-31T00:00:00Z/P1M/P1M")
+	require.ErrorIs(t, err, ErrUnexpectedChar)
+
+	_, err = ParseInterval("2023-01-01T00:00:00Z/-P65535Y65535M")
+	require.ErrorIs(t, err, safe.ErrOverflow)
+	require.ErrorAs(t, err, &parseErr)
+	require.Equal(t, len("2023-01-01T00:00:00Z/"), parseErr.Offset)
+	require.Equal(t, "-P65535Y65535M", parseErr.Token)
+}
+
+func TestIntervalOverflow(t *testing.T) {
+	start := time.Date(2023, time.January, 1, 0, 0, 0, 0, time.UTC)
+	maximum := Whilst{Years: math.MaxUint16, Months: math.MaxUint16}
+
+	_, err := NewIntervalChecked(start, Whilst{Years: math.MaxUint16, Months: math.MaxUint16, Negative: true})
+	require.ErrorIs(t, err, safe.ErrOverflow)
+	require.Panics(t, func() { NewInterval(start, Whilst{Years: math.MaxUint16, Months: math.MaxUint16, Negative: true}) })
+
+	_, err = IntervalBetweenChecked(start, maximum.When(start))
+	require.ErrorIs(t, err, safe.ErrOverflow)
+	require.Panics(t, func() { IntervalBetween(maximum.When(start), start) })
+
+	inv := NewInterval(start, maximum)
+
+	_, overlap, err := inv.IntersectChecked(inv)
+	require.ErrorIs(t, err, safe.ErrOverflow)
+	require.False(t, overlap)
+	require.Panics(t, func() { inv.Intersect(inv) })
+
+	_, single, err := inv.UnionChecked(inv)
+	require.ErrorIs(t, err, safe.ErrOverflow)
+	require.False(t, single)
+	require.Panics(t, func() { inv.Union(inv) })
+
+	intersection, overlap, err := inv.IntersectChecked(NewInterval(start, Whilst{Years: 1}))
+	require.NoError(t, err)
+	require.True(t, overlap)
+	require.Equal(t, NewInterval(start, Whilst{Years: 1}), intersection)
+
+	union, single, err := inv.UnionChecked(Interval{})
+	require.NoError(t, err)
+	require.True(t, single)
+	require.Equal(t, inv, union)
+}
+
+func TestIntervalText(t *testing.T) {
+	type record struct {
+		Interval Interval `json:"interval"`
+	}
+
+	marshaled := `{"interval":"2023-01-31T00:00:00Z/P1M"}`
+
+	unmarshaled := record{}
+
+	require.NoError(t, json.Unmarshal([]byte(marshaled), &unmarshaled))
+	require.Equal(t, "2023-01-31T00:00:00Z/P1M", unmarshaled.Interval.String())
+
+	actual, err := json.Marshal(unmarshaled)
+	require.NoError(t, err)
+	require.JSONEq(t, marshaled, string(actual))
+
+	inv := unmarshaled.Interval
+
+	require.Error(t, inv.UnmarshalText([]byte("P1M")))
+	require.Equal(t, unmarshaled.Interval, inv)
+
+	require.ErrorIs(t, inv.UnmarshalText([]byte("2023-01-01T00:00:00Z/-P65535Y65535M")), safe.ErrOverflow)
+	require.Equal(t, unmarshaled.Interval, inv)
+
+	err = json.Unmarshal([]byte(`{"interval":"2023-01-01T00:00:00Z/-P65535Y65535M"}`), &unmarshaled)
+	require.ErrorIs(t, err, safe.ErrOverflow)
+}
+
+func FuzzInterval(f *testing.F) {
+	f.Add(int64(0), uint8(1), uint8(0), int64(0), int64(1e15), uint8(0), uint8(10), int64(0), uint8(7))
+	f.Add(int64(1e17), uint8(0), uint8(31), int64(1e13), int64(1e17), uint8(2), uint8(0), int64(-1), uint8(1))
+
+	f.Fuzz(
+		func(
+			t *testing.T,
+			firstStart int64,
+			firstMonths uint8,
+			firstDays uint8,
+			firstNano int64,
+			secondStart int64,
+			secondMonths uint8,
+			secondDays uint8,
+			secondNano int64,
+			stepDays uint8,
+		) {
+			first := NewInterval(
+				time.Unix(0, firstStart).UTC(),
+				Whilst{Months: uint16(firstMonths), Days: uint16(firstDays), Nano: time.Duration(firstNano)},
+			)
+
+			second := NewInterval(
+				time.Unix(0, secondStart).UTC(),
+				Whilst{Months: uint16(secondMonths), Days: uint16(secondDays), Nano: time.Duration(secondNano)},
+			)
+
+			require.False(t, first.End().Before(first.Start()))
+			require.Equal(t, first.End(), first.Period().When(first.Start()))
+
+			intersection, overlap := first.Intersect(second)
+			require.Equal(t, first.Overlaps(second), overlap)
+			require.Equal(t, second.Overlaps(first), overlap)
+
+			if overlap {
+				require.False(t, intersection.IsEmpty())
+				require.True(t, first.Contains(intersection.Start()))
+				require.True(t, second.Contains(intersection.Start()))
+				require.False(t, intersection.End().After(first.End()))
+				require.False(t, intersection.End().After(second.End()))
+			}
+
+			if union, single := first.Union(second); single && !first.IsEmpty() && !second.IsEmpty() {
+				require.False(t, union.Start().After(first.Start()))
+				require.False(t, union.Start().After(second.Start()))
+				require.False(t, union.End().Before(first.End()))
+				require.False(t, union.End().Before(second.End()))
+			}
+
+			if stepDays == 0 {
+				return
+			}
+
+			end := first.Start()
+
+			for sub := range first.Split(Whilst{Days: uint16(stepDays)}) {
+				require.Equal(t, end, sub.Start())
+				require.False(t, sub.IsEmpty())
+
+				end = sub.End()
+			}
+
+			require.Equal(t, first.End(), end)
+		},
+	)
+}
@@ -0,0 +1,358 @@
+package whilst
+
+import (
+	"strconv"
+	"unicode/utf8"
+
+	"github.com/akramarenkov/whilst/internal/ascii"
+	"github.com/akramarenkov/whilst/internal/consts"
+
+	"github.com/akramarenkov/intspec"
+	"github.com/akramarenkov/safe"
+)
+
+// Parsing context of the ISO 8601 representation.
+type isoParser struct {
+	prs parser
+
+	origin string
+	input  string
+
+	foundNum      bool
+	foundDot      bool
+	foundTime     bool
+	foundFraction bool
+
+	idNumber int
+
+	rank int
+}
+
+// Parses an ISO 8601 representation of the duration.
+//
+// A duration string consists of a period designator P followed by decimal numbers
+// supplemented with designators of years Y, months M, weeks W and days D, then,
+// optionally, a time designator T followed by decimal numbers supplemented with
+// designators of hours H, minutes M and seconds S. Designators must be specified in
+// the listed order and each no more than once. Designator M means months before
+// the time designator and minutes after it. One of a signs - or + can be specified
+// at a beginning of a string. Spaces are not allowed.
+//
+// A value of years, months, weeks and days can only be an integer and cannot be
+// greater than 65535 for each, except that days exceeding this limit are partially
+// converted to weeks, since weeks are represented as days when combined with other
+// designators.
+//
+// Only the last value of hours, minutes or seconds may have a fractional part,
+// separated by a dot or comma. Limitations on the values of hours, minutes and
+// seconds are the same as for [Parse].
+//
+// In case of an error, [*ParseError] is returned.
+//
+// Example of strings:
+//   - P2Y3M10DT24H30M28.02006002S
+//   - -P2W
+//   - PT0,5S
+func ParseISO8601(input string) (Whilst, error) {
+	whl := Whilst{}
+
+	if err := parseISO8601(input, &whl); err != nil {
+		return Whilst{}, err
+	}
+
+	return whl, nil
+}
+
+func parseISO8601(input string, whl *Whilst) error {
+	isp := &isoParser{
+		origin: input,
+		input:  input,
+	}
+
+	isp.prs.reset()
+
+	if err := isp.parse(); err != nil {
+		return err
+	}
+
+	*whl = isp.prs.whl
+
+	return nil
+}
+
+func (isp *isoParser) parse() error {
+	if err := isp.begin(); err != nil {
+		return err
+	}
+
+	for id, char := range []byte(isp.input) {
+		if !isp.foundNum && ascii.IsDigit(char) {
+			isp.idNumber = id
+		}
+
+		if err := isp.onChar(char); err != nil {
+			return isp.newError(err, id)
+		}
+	}
+
+	if isp.foundNum {
+		return isp.newError(ErrUnitUnspecified, len(isp.input))
+	}
+
+	if isp.rank == rankNone || (isp.foundTime && isp.rank < rankHour) {
+		return isp.newError(ErrValueUnspecified, len(isp.input))
+	}
+
+	if isp.prs.whl.IsZero() {
+		isp.prs.whl.Negative = false
+	}
+
+	return nil
+}
+
+func (isp *isoParser) begin() error {
+	if isp.input == "" {
+		return isp.newError(ErrInputEmpty, 0)
+	}
+
+	switch isp.input[0] {
+	case charMinus:
+		isp.prs.whl.Negative = true
+		isp.input = isp.input[1:]
+	case charPlus:
+		isp.input = isp.input[1:]
+	}
+
+	if isp.input == "" || isp.input[0] != designatorPeriod {
+		return isp.newError(ErrPeriodUnspecified, 0)
+	}
+
+	isp.input = isp.input[1:]
+
+	return nil
+}
+
+func (isp *isoParser) onChar(char byte) error {
+	if ascii.IsDigit(char) {
+		isp.foundNum = true
+
+		if isp.foundDot {
+			isp.prs.incFraction(char)
+			return nil
+		}
+
+		return isp.prs.incInteger(char)
+	}
+
+	if char == charDot || char == charComma {
+		if !isp.foundNum {
+			return ErrNumberUnspecified
+		}
+
+		if isp.foundDot {
+			return ErrCharDotAgain
+		}
+
+		isp.foundDot = true
+
+		return nil
+	}
+
+	if char == designatorTime {
+		if isp.foundNum {
+			return ErrUnitUnspecified
+		}
+
+		if isp.foundTime {
+			return ErrTimeAgain
+		}
+
+		isp.foundTime = true
+
+		return nil
+	}
+
+	return isp.onDesignator(char)
+}
+
+func (isp *isoParser) onDesignator(char byte) error {
+	rank, err := isp.toRank(char)
+	if err != nil {
+		return err
+	}
+
+	if !isp.foundNum {
+		return ErrNumberUnspecified
+	}
+
+	if isp.foundFraction {
+		return ErrOnlyLastFraction
+	}
+
+	if rank <= isp.rank {
+		return ErrDesignatorOrder
+	}
+
+	if err := isp.addRank(rank); err != nil {
+		return err
+	}
+
+	isp.foundFraction = isp.foundDot
+	isp.foundNum = false
+	isp.foundDot = false
+	isp.rank = rank
+
+	isp.prs.reset()
+
+	return nil
+}
+
+func (isp *isoParser) addRank(rank int) error {
+	if rank != rankDay || isp.prs.fraction != 0 || isp.prs.integer <= intspec.MaxUint16 {
+		return isp.prs.addRank(rank)
+	}
+
+	// Only the excess of days is converted to weeks, so that the representation of
+	// the maximum weeks and days is parsed back
+	days := isp.prs.integer
+	weeks := (days - intspec.MaxUint16 + daysInWeek - 1) / daysInWeek
+
+	isp.prs.integer = weeks
+
+	if err := isp.prs.addCalendar(&isp.prs.whl.Weeks); err != nil {
+		return err
+	}
+
+	isp.prs.integer = days - weeks*daysInWeek
+
+	return isp.prs.addCalendar(&isp.prs.whl.Days)
+}
+
+func (isp *isoParser) toRank(char byte) (int, error) {
+	if isp.foundTime {
+		switch char {
+		case designatorHour:
+			return rankHour, nil
+		case designatorMinute:
+			return rankMinute, nil
+		case designatorSecond:
+			return rankSecond, nil
+		case designatorYear, designatorWeek, designatorDay:
+			return rankNone, ErrDateAfterTime
+		}
+
+		return rankNone, ErrUnexpectedChar
+	}
+
+	switch char {
+	case designatorYear:
+		return rankYear, nil
+	case designatorMonth:
+		return rankMonth, nil
+	case designatorWeek:
+		return rankWeek, nil
+	case designatorDay:
+		return rankDay, nil
+	case designatorHour, designatorSecond:
+		return rankNone, ErrTimeUnspecified
+	}
+
+	return rankNone, ErrUnexpectedChar
+}
+
+// Wraps the error into the ParseError with the token, which is the value being
+// parsed up to the specified position inclusive or, if there is no such value, the
+// character at the specified position.
+func (isp *isoParser) newError(err error, id int) error {
+	begin := id
+
+	if isp.foundNum {
+		begin = isp.idNumber
+	}
+
+	end := id
+
+	if id < len(isp.input) {
+		_, size := utf8.DecodeRuneInString(isp.input[id:])
+		end += size
+	}
+
+	shift := len(isp.origin) - len(isp.input)
+
+	parseErr := &ParseError{
+		Input:  isp.origin,
+		Offset: shift + begin,
+		Token:  isp.input[begin:end],
+		Err:    err,
+	}
+
+	return parseErr
+}
+
+// Returns an ISO 8601 representation of the duration.
+//
+// Hours are not converted to days, since a day is not always equal to 24 hours.
+// Weeks are represented with the designator W only if they are the only non-zero
+// component, otherwise they are converted to days, since ISO 8601 does not allow
+// combining weeks with other designators. Zero duration is represented as PT0S.
+func (whl Whilst) ISO8601() string {
+	if whl.IsZero() {
+		return specialZeroISO8601
+	}
+
+	output := make([]byte, 0, len(formatMaximumISO8601))
+
+	if whl.Negative || whl.Nano < 0 {
+		output = append(output, charMinus)
+	}
+
+	output = append(output, designatorPeriod)
+
+	if whl.Years != 0 {
+		output = strconv.AppendUint(output, uint64(whl.Years), consts.DecimalBase)
+		output = append(output, designatorYear)
+	}
+
+	if whl.Months != 0 {
+		output = strconv.AppendUint(output, uint64(whl.Months), consts.DecimalBase)
+		output = append(output, designatorMonth)
+	}
+
+	if whl.Weeks != 0 && whl.Years == 0 && whl.Months == 0 && whl.Days == 0 && whl.Nano == 0 {
+		output = strconv.AppendUint(output, uint64(whl.Weeks), consts.DecimalBase)
+		output = append(output, designatorWeek)
+
+		return string(output)
+	}
+
+	if days := uint64(whl.Weeks)*daysInWeek + uint64(whl.Days); days != 0 {
+		output = strconv.AppendUint(output, days, consts.DecimalBase)
+		output = append(output, designatorDay)
+	}
+
+	if whl.Nano == 0 {
+		return string(output)
+	}
+
+	output = append(output, designatorTime)
+
+	hours, minutes, seconds, fraction := splitNano(safe.Abs(whl.Nano))
+
+	if hours != 0 {
+		output = strconv.AppendUint(output, hours, consts.DecimalBase)
+		output = append(output, designatorHour)
+	}
+
+	if minutes != 0 {
+		output = strconv.AppendUint(output, minutes, consts.DecimalBase)
+		output = append(output, designatorMinute)
+	}
+
+	if seconds != 0 || fraction != 0 {
+		output = strconv.AppendUint(output, seconds, consts.DecimalBase)
+		output = appendFraction(output, fraction)
+		output = append(output, designatorSecond)
+	}
+
+	return string(output)
+}
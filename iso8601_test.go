@@ -0,0 +1,221 @@
+package whilst
+
+import (
+	"math"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseISO8601(t *testing.T) {
+	whl, err := ParseISO8601("P2Y3M10DT24H30M28.02006002S")
+	require.NoError(t, err)
+	require.Equal(t, Whilst{Years: 2, Months: 3, Days: 10, Nano: 88228020060020}, whl)
+
+	whl, err = ParseISO8601("-P2Y3M10DT23.5H")
+	require.NoError(t, err)
+	require.Equal(
+		t,
+		Whilst{Years: 2, Months: 3, Days: 10, Nano: -23*time.Hour - 30*time.Minute, Negative: true},
+		whl,
+	)
+
+	whl, err = ParseISO8601("+P1M")
+	require.NoError(t, err)
+	require.Equal(t, Whilst{Months: 1}, whl)
+
+	whl, err = ParseISO8601("PT1M")
+	require.NoError(t, err)
+	require.Equal(t, Whilst{Nano: time.Minute}, whl)
+
+	whl, err = ParseISO8601("P1MT1M")
+	require.NoError(t, err)
+	require.Equal(t, Whilst{Months: 1, Nano: time.Minute}, whl)
+
+	whl, err = ParseISO8601("P2W")
+	require.NoError(t, err)
+	require.Equal(t, Whilst{Weeks: 2}, whl)
+
+	whl, err = ParseISO8601("P1W3D")
+	require.NoError(t, err)
+	require.Equal(t, Whilst{Weeks: 1, Days: 3}, whl)
+
+	whl, err = ParseISO8601("PT0,5S")
+	require.NoError(t, err)
+	require.Equal(t, Whilst{Nano: 500 * time.Millisecond}, whl)
+
+	whl, err = ParseISO8601("PT1H0.5M")
+	require.NoError(t, err)
+	require.Equal(t, Whilst{Nano: time.Hour + 30*time.Second}, whl)
+
+	whl, err = ParseISO8601("-PT0S")
+	require.NoError(t, err)
+	require.Equal(t, Whilst{}, whl)
+
+	whl, err = ParseISO8601("P0D")
+	require.NoError(t, err)
+	require.Equal(t, Whilst{}, whl)
+
+	whl, err = ParseISO8601("P65535Y65535M65535D")
+	require.NoError(t, err)
+	require.Equal(t, Whilst{Years: 65535, Months: 65535, Days: 65535}, whl)
+
+	whl, err = ParseISO8601("P70000D")
+	require.NoError(t, err)
+	require.Equal(t, Whilst{Weeks: 638, Days: 65534}, whl)
+
+	whl, err = ParseISO8601("P524280D")
+	require.NoError(t, err)
+	require.Equal(t, Whilst{Weeks: 65535, Days: 65535}, whl)
+
+	whl, err = ParseISO8601("PT9223372036.854775807S")
+	require.NoError(t, err)
+	require.Equal(t, Whilst{Nano: math.MaxInt64}, whl)
+
+	whl, err = ParseISO8601("-PT2562047H47M16.854775808S")
+	require.NoError(t, err)
+	require.Equal(t, Whilst{Nano: math.MinInt64, Negative: true}, whl)
+}
+
+func TestParseISO8601Error(t *testing.T) {
+	inputs := map[string]error{
+		"":                       ErrInputEmpty,
+		"-":                      ErrPeriodUnspecified,
+		"1Y":                     ErrPeriodUnspecified,
+		"--P1Y":                  ErrPeriodUnspecified,
+		" P1Y":                   ErrPeriodUnspecified,
+		"P":                      ErrValueUnspecified,
+		"PT":                     ErrValueUnspecified,
+		"P1DT":                   ErrValueUnspecified,
+		"P1":                     ErrUnitUnspecified,
+		"P1T1H":                  ErrUnitUnspecified,
+		"PY":                     ErrNumberUnspecified,
+		"P.5Y":                   ErrNumberUnspecified,
+		"PT1H1.5":                ErrUnitUnspecified,
+		"P1H":                    ErrTimeUnspecified,
+		"P1S":                    ErrTimeUnspecified,
+		"PT1D":                   ErrDateAfterTime,
+		"PT1Y":                   ErrDateAfterTime,
+		"PT1W":                   ErrDateAfterTime,
+		"P1TT1H":                 ErrUnitUnspecified,
+		"PTT1H":                  ErrTimeAgain,
+		"P1DTT1H":                ErrTimeAgain,
+		"P1M1Y":                  ErrDesignatorOrder,
+		"P1D1W":                  ErrDesignatorOrder,
+		"P1Y1Y":                  ErrDesignatorOrder,
+		"PT1S1M":                 ErrDesignatorOrder,
+		"PT1M1M":                 ErrDesignatorOrder,
+		"PT1.5H1M":               ErrOnlyLastFraction,
+		"PT1.5.5S":               ErrCharDotAgain,
+		"PT1,5,5S":               ErrCharDotAgain,
+		"P1.5Y":                  ErrOnlyInteger,
+		"P1.5M":                  ErrOnlyInteger,
+		"P1.5W":                  ErrOnlyInteger,
+		"P1.5D":                  ErrOnlyInteger,
+		"P1X":                    ErrUnexpectedChar,
+		"P1y":                    ErrUnexpectedChar,
+		"P1Y 1D":                 ErrUnexpectedChar,
+		"P-1Y":                   ErrUnexpectedChar,
+		"P65536Y":                nil,
+		"P65536W":                nil,
+		"P524281D":               nil,
+		"PT9223372036854775808S": nil,
+		"PT2562048H":             nil,
+	}
+
+	for input, expected := range inputs {
+		whl, err := ParseISO8601(input)
+		require.Error(t, err, "input: %v", input)
+		require.Equal(t, Whilst{}, whl, "input: %v", input)
+
+		if expected != nil {
+			require.ErrorIs(t, err, expected, "input: %v", input)
+		}
+	}
+}
+
+func TestISO8601(t *testing.T) {
+	require.Equal(t, "PT0S", Whilst{}.ISO8601())
+	require.Equal(t, "PT0S", Whilst{Negative: true}.ISO8601())
+	require.Equal(t, "P2Y", Whilst{Years: 2}.ISO8601())
+	require.Equal(t, "-P3M", Whilst{Months: 3, Negative: true}.ISO8601())
+	require.Equal(t, "P10D", Whilst{Days: 10}.ISO8601())
+	require.Equal(t, "P2W", Whilst{Weeks: 2}.ISO8601())
+	require.Equal(t, "-P2W", Whilst{Weeks: 2, Negative: true}.ISO8601())
+	require.Equal(t, "P10D", Whilst{Weeks: 1, Days: 3}.ISO8601())
+	require.Equal(t, "P1Y14D", Whilst{Years: 1, Weeks: 2}.ISO8601())
+	require.Equal(t, "P7DT1H", Whilst{Weeks: 1, Nano: time.Hour}.ISO8601())
+	require.Equal(t, "P524280D", Whilst{Weeks: 65535, Days: 65535}.ISO8601())
+	require.Equal(t, "PT1M", Whilst{Nano: time.Minute}.ISO8601())
+	require.Equal(t, "PT0.000000001S", Whilst{Nano: 1}.ISO8601())
+	require.Equal(t, "-PT1H1S", Whilst{Nano: -time.Hour - time.Second}.ISO8601())
+
+	require.Equal(
+		t,
+		"-P2Y3M10DT24H30M28.02006002S",
+		Whilst{Years: 2, Months: 3, Days: 10, Nano: 88228020060020, Negative: true}.ISO8601(),
+	)
+
+	require.Equal(
+		t,
+		"-P65535Y65535M65535DT2562047H47M16.854775808S",
+		Whilst{Years: 65535, Months: 65535, Days: 65535, Nano: math.MinInt64}.ISO8601(),
+	)
+}
+
+func FuzzISO8601(f *testing.F) {
+	f.Add(int64(math.MaxInt64), uint16(math.MaxUint16), uint16(0), uint16(1), false)
+	f.Add(int64(math.MinInt64), uint16(0), uint16(math.MaxUint16), uint16(1), false)
+	f.Add(int64(1), uint16(1), uint16(1), uint16(math.MaxUint16), true)
+
+	f.Fuzz(
+		func(t *testing.T, nano int64, days, months, years uint16, negative bool) {
+			origin := Whilst{
+				Nano:     time.Duration(nano),
+				Days:     days,
+				Months:   months,
+				Years:    years,
+				Negative: negative,
+			}
+
+			expected := origin.normalize()
+
+			if origin.IsZero() {
+				expected = Whilst{}
+			}
+
+			parsed, err := ParseISO8601(origin.ISO8601())
+			require.NoError(t, err)
+			require.Equal(t, expected, parsed)
+		},
+	)
+}
+
+func FuzzParseISO8601(f *testing.F) {
+	f.Add("-P2Y3M1W10DT23.5H59M58.01003001S")
+	f.Add("PT0,5S")
+
+	f.Fuzz(
+		func(t *testing.T, input string) {
+			parsed, err := ParseISO8601(input)
+			if err != nil {
+				return
+			}
+
+			reparsed, err := ParseISO8601(parsed.ISO8601())
+			require.NoError(t, err)
+
+			// Weeks can be converted to days when combined with other designators
+			require.Equal(t, parsed.Years, reparsed.Years)
+			require.Equal(t, parsed.Months, reparsed.Months)
+			require.Equal(t, parsed.Nano, reparsed.Nano)
+			require.Equal(t, parsed.Negative, reparsed.Negative)
+			require.Equal(
+				t,
+				int(parsed.Weeks)*daysInWeek+int(parsed.Days),
+				int(reparsed.Weeks)*daysInWeek+int(reparsed.Days),
+			)
+		},
+	)
+}
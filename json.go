@@ -0,0 +1,139 @@
+package whilst
+
+import (
+	"bytes"
+	"encoding/json"
+	"time"
+)
+
+// Representation of the duration in the form of a JSON object.
+type jsonObject struct {
+	Years    uint16 `json:"years"`
+	Months   uint16 `json:"months"`
+	Weeks    uint16 `json:"weeks,omitempty"`
+	Days     uint16 `json:"days"`
+	Nano     int64  `json:"nano"`
+	Negative bool   `json:"negative"`
+}
+
+// Duration that is encoded to JSON in the form of an object with separate fields for
+// each of the components, e.g. {"years":2,"months":3,"days":10,
+// "nano":-3600000000000,"negative":true}.
+//
+// Decoding from JSON accepts the same representations as for [Whilst].
+type Structured struct {
+	Whilst
+}
+
+// Returns a JSON representation of the duration in the form of a string
+// returned by [Whilst.String].
+//
+// Implements the [json.Marshaler] interface.
+func (whl Whilst) MarshalJSON() ([]byte, error) {
+	output := make([]byte, 0, len(formatMaximum)+len(`""`))
+
+	output = append(output, '"')
+	output = whl.appendString(output)
+	output = append(output, '"')
+
+	return output, nil
+}
+
+// Parses a JSON representation of the duration.
+//
+// Implements the [json.Unmarshaler] interface. Accepts a string in the format of
+// [Parse], an object in the format of [Structured] and an integer number which is
+// interpreted as a number of nanoseconds as for [time.Duration]. JSON null is
+// ignored. In case of an error, the duration remains unchanged.
+func (whl *Whilst) UnmarshalJSON(input []byte) error {
+	input = bytes.TrimSpace(input)
+
+	if len(input) == 0 {
+		return ErrInputEmpty
+	}
+
+	if string(input) == specialNullJSON {
+		return nil
+	}
+
+	switch input[0] {
+	case '"':
+		var text string
+
+		if err := json.Unmarshal(input, &text); err != nil {
+			return err
+		}
+
+		return whl.UnmarshalText([]byte(text))
+	case '{':
+		return whl.unmarshalJSONObject(input)
+	}
+
+	var nano int64
+
+	if err := json.Unmarshal(input, &nano); err != nil {
+		return err
+	}
+
+	*whl = Whilst{Nano: time.Duration(nano)}.normalize()
+
+	return nil
+}
+
+func (whl *Whilst) unmarshalJSONObject(input []byte) error {
+	decoder := json.NewDecoder(bytes.NewReader(input))
+	decoder.DisallowUnknownFields()
+
+	var object jsonObject
+
+	if err := decoder.Decode(&object); err != nil {
+		return err
+	}
+
+	if decoder.InputOffset() != int64(len(input)) {
+		return ErrUnexpectedChar
+	}
+
+	unmarshaled := Whilst{
+		Nano:     time.Duration(object.Nano),
+		Days:     object.Days,
+		Weeks:    object.Weeks,
+		Months:   object.Months,
+		Years:    object.Years,
+		Negative: object.Negative,
+	}
+
+	if unmarshaled.IsZero() {
+		unmarshaled.Negative = false
+	}
+
+	*whl = unmarshaled.normalize()
+
+	return nil
+}
+
+// Returns a JSON representation of the duration in the form of an object.
+//
+// Implements the [json.Marshaler] interface.
+func (str Structured) MarshalJSON() ([]byte, error) {
+	normalized := str.normalize()
+
+	object := jsonObject{
+		Years:    normalized.Years,
+		Months:   normalized.Months,
+		Weeks:    normalized.Weeks,
+		Days:     normalized.Days,
+		Nano:     int64(normalized.Nano),
+		Negative: normalized.Negative && !normalized.IsZero(),
+	}
+
+	return json.Marshal(object)
+}
+
+// Parses a JSON representation of the duration.
+//
+// Implements the [json.Unmarshaler] interface. Accepts the same representations as
+// [Whilst.UnmarshalJSON].
+func (str *Structured) UnmarshalJSON(input []byte) error {
+	return str.Whilst.UnmarshalJSON(input)
+}
@@ -0,0 +1,166 @@
+package whilst
+
+import (
+	"encoding/json"
+	"math"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestMarshalJSON(t *testing.T) {
+	whl := Whilst{Years: 2, Months: 3, Days: 10, Nano: 88228020060020, Negative: true}
+
+	output, err := json.Marshal(whl)
+	require.NoError(t, err)
+	require.JSONEq(t, `"-2y3mo10d24h30m28.02006002s"`, string(output))
+
+	output, err = json.Marshal(Whilst{Nano: 30 * time.Microsecond})
+	require.NoError(t, err)
+	require.JSONEq(t, `"30µs"`, string(output))
+
+	output, err = json.Marshal(Whilst{})
+	require.NoError(t, err)
+	require.JSONEq(t, `"0s"`, string(output))
+}
+
+func TestMarshalJSONStructured(t *testing.T) {
+	whl := Whilst{Years: 2, Months: 3, Days: 10, Nano: 88228020060020, Negative: true}
+
+	output, err := json.Marshal(Structured{whl})
+	require.NoError(t, err)
+	require.JSONEq(
+		t,
+		`{"years":2,"months":3,"days":10,"nano":-88228020060020,"negative":true}`,
+		string(output),
+	)
+
+	output, err = json.Marshal(Structured{Whilst{Weeks: 2, Days: 3}})
+	require.NoError(t, err)
+	require.JSONEq(
+		t,
+		`{"years":0,"months":0,"weeks":2,"days":3,"nano":0,"negative":false}`,
+		string(output),
+	)
+
+	output, err = json.Marshal(Structured{Whilst{Negative: true}})
+	require.NoError(t, err)
+	require.JSONEq(
+		t,
+		`{"years":0,"months":0,"days":0,"nano":0,"negative":false}`,
+		string(output),
+	)
+}
+
+func TestUnmarshalJSON(t *testing.T) {
+	expected := Whilst{Years: 2, Months: 3, Days: 10, Nano: -88228e9, Negative: true}
+
+	var whl Whilst
+
+	require.NoError(t, json.Unmarshal([]byte(`"-2y3mo10d23.5h59.5m58s"`), &whl))
+	require.Equal(t, expected, whl)
+
+	whl = Whilst{}
+
+	require.NoError(
+		t,
+		json.Unmarshal(
+			[]byte(`{"years":2,"months":3,"days":10,"nano":88228000000000,"negative":true}`),
+			&whl,
+		),
+	)
+	require.Equal(t, expected, whl)
+
+	whl = Whilst{}
+
+	require.NoError(
+		t,
+		json.Unmarshal(
+			[]byte(`{"years":2,"months":3,"days":10,"nano":-88228000000000}`),
+			&whl,
+		),
+	)
+	require.Equal(t, expected, whl)
+
+	require.NoError(t, json.Unmarshal([]byte(`null`), &whl))
+	require.Equal(t, expected, whl)
+
+	require.NoError(t, json.Unmarshal([]byte(`-9223372036854775808`), &whl))
+	require.Equal(t, Whilst{Nano: math.MinInt64, Negative: true}, whl)
+
+	require.NoError(t, json.Unmarshal([]byte(`{"weeks":2,"days":3}`), &whl))
+	require.Equal(t, Whilst{Weeks: 2, Days: 3}, whl)
+
+	require.NoError(t, json.Unmarshal([]byte(`{"negative":true}`), &whl))
+	require.Equal(t, Whilst{}, whl)
+
+	var str Structured
+
+	require.NoError(t, json.Unmarshal([]byte(`" - 2y 3mo 10d 88228s"`), &str))
+	require.Equal(t, Structured{expected}, str)
+}
+
+func TestUnmarshalJSONError(t *testing.T) {
+	inputs := []string{
+		``,
+		`  `,
+		`"2.5y"`,
+		`"2y`,
+		`1.5`,
+		`9223372036854775808`,
+		`true`,
+		`[]`,
+		`{"years":65536}`,
+		`{"hours":1}`,
+		`{"years":1}}`,
+		`{"nano":"1s"}`,
+	}
+
+	for _, input := range inputs {
+		whl := Whilst{Years: 1}
+
+		require.Error(t, whl.UnmarshalJSON([]byte(input)), "input: %v", input)
+		require.Equal(t, Whilst{Years: 1}, whl, "input: %v", input)
+	}
+}
+
+func FuzzJSON(f *testing.F) {
+	f.Add(int64(math.MaxInt64), uint16(math.MaxUint16), uint16(0), uint16(1), false)
+	f.Add(int64(math.MinInt64), uint16(0), uint16(math.MaxUint16), uint16(1), false)
+	f.Add(int64(1), uint16(1), uint16(1), uint16(math.MaxUint16), true)
+
+	f.Fuzz(
+		func(t *testing.T, nano int64, days, months, years uint16, negative bool) {
+			origin := Whilst{
+				Nano:     time.Duration(nano),
+				Days:     days,
+				Months:   months,
+				Years:    years,
+				Negative: negative,
+			}
+
+			expected := origin.normalize()
+
+			if origin.IsZero() {
+				expected = Whilst{}
+			}
+
+			output, err := json.Marshal(origin)
+			require.NoError(t, err)
+
+			var unmarshaled Whilst
+
+			require.NoError(t, json.Unmarshal(output, &unmarshaled))
+			require.Equal(t, expected, unmarshaled)
+
+			output, err = json.Marshal(Structured{origin})
+			require.NoError(t, err)
+
+			unmarshaled = Whilst{}
+
+			require.NoError(t, json.Unmarshal(output, &unmarshaled))
+			require.Equal(t, expected, unmarshaled)
+		},
+	)
+}
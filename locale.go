@@ -0,0 +1,162 @@
+package whilst
+
+// Unit of the long-form representation of the duration.
+type Unit int
+
+const (
+	UnitYear Unit = iota
+	UnitMonth
+	UnitWeek
+	UnitDay
+	UnitHour
+	UnitMinute
+	UnitSecond
+	UnitMillisecond
+	UnitMicrosecond
+	UnitNanosecond
+	unitsQuantity
+)
+
+// Plural category of a number according to the CLDR plural rules.
+type PluralCategory int
+
+const (
+	PluralOther PluralCategory = iota
+	PluralZero
+	PluralOne
+	PluralTwo
+	PluralFew
+	PluralMany
+)
+
+// Locale of the long-form representation of the duration.
+//
+// Built-in locales are [English] and [Russian].
+type Locale interface {
+	// Returns the cardinal plural category of the integer number
+	Plural(number uint64) PluralCategory
+	// Returns the name of the unit in the form corresponding to the plural category
+	UnitName(unit Unit, category PluralCategory) string
+	// Returns the separator between components used by default, e.g. ", "
+	Separator() string
+	// Returns the separator between the last two components used by default,
+	// e.g. " and "
+	Conjunction() string
+}
+
+// Returns the English locale.
+func English() Locale {
+	return english{}
+}
+
+// Returns the Russian locale.
+func Russian() Locale {
+	return russian{}
+}
+
+type english struct{}
+
+// Names of units in the singular and plural forms.
+//
+//nolint:gochecknoglobals // To increase performance
+var englishNames = [unitsQuantity][2]string{
+	{"year", "years"},
+	{"month", "months"},
+	{"week", "weeks"},
+	{"day", "days"},
+	{"hour", "hours"},
+	{"minute", "minutes"},
+	{"second", "seconds"},
+	{"millisecond", "milliseconds"},
+	{"microsecond", "microseconds"},
+	{"nanosecond", "nanoseconds"},
+}
+
+func (english) Plural(number uint64) PluralCategory {
+	if number == 1 {
+		return PluralOne
+	}
+
+	return PluralOther
+}
+
+func (english) UnitName(unit Unit, category PluralCategory) string {
+	if unit < 0 || unit >= unitsQuantity {
+		return ""
+	}
+
+	if category == PluralOne {
+		return englishNames[unit][0]
+	}
+
+	return englishNames[unit][1]
+}
+
+func (english) Separator() string {
+	return ", "
+}
+
+func (english) Conjunction() string {
+	return " and "
+}
+
+type russian struct{}
+
+// Names of units in the forms of the one, few and many plural categories, the form
+// of the few category is also used for the other category.
+//
+//nolint:gochecknoglobals // To increase performance
+var russianNames = [unitsQuantity][3]string{
+	{"год", "года", "лет"},
+	{"месяц", "месяца", "месяцев"},
+	{"неделя", "недели", "недель"},
+	{"день", "дня", "дней"},
+	{"час", "часа", "часов"},
+	{"минута", "минуты", "минут"},
+	{"секунда", "секунды", "секунд"},
+	{"миллисекунда", "миллисекунды", "миллисекунд"},
+	{"микросекунда", "микросекунды", "микросекунд"},
+	{"наносекунда", "наносекунды", "наносекунд"},
+}
+
+func (russian) Plural(number uint64) PluralCategory {
+	const (
+		decimal = 10
+		hundred = 100
+	)
+
+	units := number % decimal
+	tens := number % hundred
+
+	switch {
+	case units == 1 && tens != 11:
+		return PluralOne
+	case units >= 2 && units <= 4 && (tens < 12 || tens > 14):
+		return PluralFew
+	}
+
+	return PluralMany
+}
+
+func (russian) UnitName(unit Unit, category PluralCategory) string {
+	if unit < 0 || unit >= unitsQuantity {
+		return ""
+	}
+
+	switch category {
+	case PluralOne:
+		return russianNames[unit][0]
+	case PluralMany:
+		return russianNames[unit][2]
+	}
+
+	return russianNames[unit][1]
+}
+
+func (russian) Separator() string {
+	return ", "
+}
+
+func (russian) Conjunction() string {
+	return " и "
+}
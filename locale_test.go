@@ -0,0 +1,236 @@
+package whilst
+
+import (
+	"math"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+// Locale used to check that locales are pluggable.
+type testGerman struct{}
+
+func (testGerman) Plural(number uint64) PluralCategory {
+	if number == 1 {
+		return PluralOne
+	}
+
+	return PluralOther
+}
+
+func (testGerman) UnitName(unit Unit, category PluralCategory) string {
+	names := map[Unit][2]string{
+		UnitYear:  {"Jahr", "Jahre"},
+		UnitMonth: {"Monat", "Monate"},
+		UnitDay:   {"Tag", "Tage"},
+		UnitHour:  {"Stunde", "Stunden"},
+	}
+
+	if category == PluralOne {
+		return names[unit][0]
+	}
+
+	return names[unit][1]
+}
+
+func (testGerman) Separator() string {
+	return ", "
+}
+
+func (testGerman) Conjunction() string {
+	return " und "
+}
+
+func TestPluralEnglish(t *testing.T) {
+	expected := map[uint64]PluralCategory{
+		0:              PluralOther,
+		1:              PluralOne,
+		2:              PluralOther,
+		11:             PluralOther,
+		21:             PluralOther,
+		math.MaxUint64: PluralOther,
+	}
+
+	for number, category := range expected {
+		require.Equal(t, category, English().Plural(number), "number: %v", number)
+	}
+}
+
+func TestPluralRussian(t *testing.T) {
+	expected := map[uint64]PluralCategory{
+		0:    PluralMany,
+		1:    PluralOne,
+		2:    PluralFew,
+		4:    PluralFew,
+		5:    PluralMany,
+		11:   PluralMany,
+		12:   PluralMany,
+		14:   PluralMany,
+		21:   PluralOne,
+		22:   PluralFew,
+		25:   PluralMany,
+		101:  PluralOne,
+		111:  PluralMany,
+		112:  PluralMany,
+		1001: PluralOne,
+		1022: PluralFew,
+	}
+
+	for number, category := range expected {
+		require.Equal(t, category, Russian().Plural(number), "number: %v", number)
+	}
+}
+
+func TestUnitName(t *testing.T) {
+	require.Equal(t, "year", English().UnitName(UnitYear, PluralOne))
+	require.Equal(t, "years", English().UnitName(UnitYear, PluralOther))
+	require.Equal(t, "nanoseconds", English().UnitName(UnitNanosecond, PluralMany))
+	require.Empty(t, English().UnitName(Unit(-1), PluralOne))
+	require.Empty(t, English().UnitName(unitsQuantity, PluralOne))
+
+	require.Equal(t, "год", Russian().UnitName(UnitYear, PluralOne))
+	require.Equal(t, "года", Russian().UnitName(UnitYear, PluralFew))
+	require.Equal(t, "лет", Russian().UnitName(UnitYear, PluralMany))
+	require.Equal(t, "года", Russian().UnitName(UnitYear, PluralOther))
+	require.Empty(t, Russian().UnitName(Unit(-1), PluralOne))
+	require.Empty(t, Russian().UnitName(unitsQuantity, PluralOne))
+}
+
+func TestHumanizeLocale(t *testing.T) {
+	russian := HumanizeOptions{Locale: Russian()}
+
+	require.Equal(t, "1 год", Whilst{Years: 1}.Humanize(russian))
+	require.Equal(t, "2 года", Whilst{Years: 2}.Humanize(russian))
+	require.Equal(t, "5 лет", Whilst{Years: 5}.Humanize(russian))
+	require.Equal(t, "0 секунд", Whilst{}.Humanize(russian))
+	require.Equal(
+		t,
+		"21 год, 3 месяца, 1 неделя и 11 дней",
+		Whilst{Years: 21, Months: 3, Weeks: 1, Days: 11}.Humanize(russian),
+	)
+	require.Equal(
+		t,
+		"-22 часа и 1 минута",
+		Whilst{Nano: -22*time.Hour - time.Minute}.Humanize(russian),
+	)
+
+	require.Equal(
+		t,
+		"1 Jahr, 2 Monate und 1 Tag",
+		Whilst{Years: 1, Months: 2, Days: 1}.Humanize(HumanizeOptions{Locale: testGerman{}}),
+	)
+}
+
+func TestParseHumanized(t *testing.T) {
+	expected := []struct {
+		Input    string
+		Options  HumanizeOptions
+		Expected Whilst
+	}{
+		{
+			Input:    "2 years, 3 months and 10 days",
+			Expected: Whilst{Years: 2, Months: 3, Days: 10},
+		},
+		{
+			Input:    " - 1 Year,  1 week  and 25 HOURS ",
+			Expected: Whilst{Years: 1, Weeks: 1, Nano: -25 * time.Hour, Negative: true},
+		},
+		{
+			Input:    "+1.5 hours 500 milliseconds 1 microsecond 1 nanosecond",
+			Expected: Whilst{Nano: 90*time.Minute + 500*time.Millisecond + 1001},
+		},
+		{
+			Input:    "-0 seconds",
+			Expected: Whilst{},
+		},
+		{
+			Input:    "21 год, 3 месяца, 1 неделя и 11 дней",
+			Options:  HumanizeOptions{Locale: Russian()},
+			Expected: Whilst{Years: 21, Months: 3, Weeks: 1, Days: 11},
+		},
+		{
+			Input:    "5 ЛЕТ 2 минуты",
+			Options:  HumanizeOptions{Locale: Russian()},
+			Expected: Whilst{Years: 5, Nano: 2 * time.Minute},
+		},
+		{
+			Input:    "1 Jahr, 2 Monate und 1 Tag",
+			Options:  HumanizeOptions{Locale: testGerman{}},
+			Expected: Whilst{Years: 1, Months: 2, Days: 1},
+		},
+		{
+			Input:    "2 years; 3 months; 10 days",
+			Options:  HumanizeOptions{Separator: "; ", Conjunction: "; "},
+			Expected: Whilst{Years: 2, Months: 3, Days: 10},
+		},
+	}
+
+	for _, item := range expected {
+		whl, err := ParseHumanized(item.Input, item.Options)
+		require.NoError(t, err, "input: %v", item.Input)
+		require.Equal(t, item.Expected, whl, "input: %v", item.Input)
+	}
+}
+
+func TestParseHumanizedError(t *testing.T) {
+	inputs := map[string]error{
+		"":                   ErrInputEmpty,
+		"  ":                 ErrInputEmpty,
+		"-":                  ErrNumberUnspecified,
+		"and":                ErrNumberUnspecified,
+		"2 years 3":          ErrUnitUnspecified,
+		"2 fortnights":       ErrUnexpectedUnit,
+		"years":              ErrUnexpectedChar,
+		"1.5 years":          ErrOnlyInteger,
+		"1..5 hours":         ErrCharDotAgain,
+		"65536 days":         nil,
+		"2 years 5 лет":      ErrUnexpectedUnit,
+		"9223372037 seconds": nil,
+	}
+
+	for input, expected := range inputs {
+		whl, err := ParseHumanized(input, HumanizeOptions{})
+		require.Error(t, err, "input: %v", input)
+		require.Equal(t, Whilst{}, whl, "input: %v", input)
+
+		if expected != nil {
+			require.ErrorIs(t, err, expected, "input: %v", input)
+		}
+	}
+}
+
+func FuzzHumanize(f *testing.F) {
+	f.Add(int64(math.MaxInt64), uint16(math.MaxUint16), uint16(1), uint16(11), uint16(1), false, false)
+	f.Add(int64(math.MinInt64), uint16(0), uint16(0), uint16(math.MaxUint16), uint16(1), false, true)
+	f.Add(int64(1), uint16(1), uint16(2), uint16(3), uint16(math.MaxUint16), true, true)
+
+	f.Fuzz(
+		func(t *testing.T, nano int64, days, weeks, months, years uint16, negative, russian bool) {
+			origin := Whilst{
+				Nano:     time.Duration(nano),
+				Days:     days,
+				Weeks:    weeks,
+				Months:   months,
+				Years:    years,
+				Negative: negative,
+			}
+
+			expected := origin.normalize()
+
+			if origin.IsZero() {
+				expected = Whilst{}
+			}
+
+			opts := HumanizeOptions{}
+
+			if russian {
+				opts.Locale = Russian()
+			}
+
+			parsed, err := ParseHumanized(origin.Humanize(opts), opts)
+			require.NoError(t, err)
+			require.Equal(t, expected, parsed)
+		},
+	)
+}
@@ -0,0 +1,77 @@
+package whilst
+
+import "time"
+
+// Policy of handling a shift by months and years to a month that does not contain
+// the day of month of the initial time, e.g. a shift from January 31 by one month.
+type MonthEnd int
+
+const (
+	// Overflowing days are carried over to the next month, just like [time.AddDate]
+	// and [Whilst.When] do, e.g. January 31 + 1mo = March 3 of a non-leap year.
+	MonthEndNormalize MonthEnd = iota
+	// Day of month is limited by the last day of the target month,
+	// e.g. January 31 + 1mo = February 28 of a non-leap year.
+	MonthEndClamp
+	// [ErrDayNonexistent] is returned.
+	MonthEndError
+)
+
+// Returns a time shifted by the duration with the specified policy of handling
+// the end of month.
+//
+// First, the shift by years and months is performed, taking into account the policy,
+// then the shift by weeks and days and, finally, by nanoseconds. Unknown policy is
+// treated as [MonthEndNormalize].
+func (whl Whilst) WhenWith(from time.Time, policy MonthEnd) (time.Time, error) {
+	if policy != MonthEndClamp && policy != MonthEndError {
+		return whl.When(from), nil
+	}
+
+	sgn := whl.signed()
+
+	year, month, day := from.Date()
+	hour, minute, second := from.Clock()
+
+	year += int(sgn.years)
+	month += time.Month(sgn.months)
+
+	if last := lastDayOfMonth(year, month); day > last {
+		if policy == MonthEndError {
+			return time.Time{}, ErrDayNonexistent
+		}
+
+		day = last
+	}
+
+	shifted := time.Date(
+		year,
+		month,
+		day+int(sgn.weeks*daysInWeek+sgn.days),
+		hour,
+		minute,
+		second,
+		from.Nanosecond(),
+		from.Location(),
+	)
+
+	return shifted.Add(time.Duration(sgn.nano)), nil
+}
+
+// Returns a time.Duration representation of the duration with the specified policy
+// of handling the end of month.
+//
+// See [Whilst.Duration] and [Whilst.WhenWith].
+func (whl Whilst) DurationWith(from time.Time, policy MonthEnd) (time.Duration, error) {
+	when, err := whl.WhenWith(from, policy)
+	if err != nil {
+		return 0, err
+	}
+
+	return when.Sub(from), nil
+}
+
+// Returns the last day of the month, month may be out of the usual range.
+func lastDayOfMonth(year int, month time.Month) int {
+	return time.Date(year, month+1, 0, 0, 0, 0, 0, time.UTC).Day()
+}
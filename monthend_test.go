@@ -0,0 +1,178 @@
+package whilst
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestWhenWith(t *testing.T) {
+	jan31 := time.Date(2023, time.January, 31, 12, 0, 0, 0, time.UTC)
+	mar31 := time.Date(2023, time.March, 31, 12, 0, 0, 0, time.UTC)
+	feb29 := time.Date(2024, time.February, 29, 12, 0, 0, 0, time.UTC)
+
+	expected := []struct {
+		Whilst   Whilst
+		From     time.Time
+		Policy   MonthEnd
+		Expected time.Time
+		Err      error
+	}{
+		{
+			Whilst:   Whilst{Months: 1},
+			From:     jan31,
+			Policy:   MonthEndNormalize,
+			Expected: time.Date(2023, time.March, 3, 12, 0, 0, 0, time.UTC),
+		},
+		{
+			Whilst:   Whilst{Months: 1},
+			From:     jan31,
+			Policy:   MonthEndClamp,
+			Expected: time.Date(2023, time.February, 28, 12, 0, 0, 0, time.UTC),
+		},
+		{
+			Whilst: Whilst{Months: 1},
+			From:   jan31,
+			Policy: MonthEndError,
+			Err:    ErrDayNonexistent,
+		},
+		{
+			Whilst:   Whilst{Months: 1, Days: 1, Nano: time.Hour},
+			From:     jan31,
+			Policy:   MonthEndClamp,
+			Expected: time.Date(2023, time.March, 1, 13, 0, 0, 0, time.UTC),
+		},
+		{
+			Whilst:   Whilst{Months: 1, Negative: true},
+			From:     mar31,
+			Policy:   MonthEndClamp,
+			Expected: time.Date(2023, time.February, 28, 12, 0, 0, 0, time.UTC),
+		},
+		{
+			Whilst: Whilst{Months: 1, Negative: true},
+			From:   mar31,
+			Policy: MonthEndError,
+			Err:    ErrDayNonexistent,
+		},
+		{
+			Whilst:   Whilst{Years: 1},
+			From:     feb29,
+			Policy:   MonthEndClamp,
+			Expected: time.Date(2025, time.February, 28, 12, 0, 0, 0, time.UTC),
+		},
+		{
+			Whilst:   Whilst{Years: 4},
+			From:     feb29,
+			Policy:   MonthEndError,
+			Expected: time.Date(2028, time.February, 29, 12, 0, 0, 0, time.UTC),
+		},
+		{
+			Whilst:   Whilst{Months: 13},
+			From:     jan31,
+			Policy:   MonthEndClamp,
+			Expected: time.Date(2024, time.February, 29, 12, 0, 0, 0, time.UTC),
+		},
+		{
+			Whilst:   Whilst{Months: 2},
+			From:     jan31,
+			Policy:   MonthEndError,
+			Expected: mar31,
+		},
+		{
+			Whilst:   Whilst{Months: 1, Weeks: 1, Days: 1},
+			From:     jan31,
+			Policy:   MonthEndClamp,
+			Expected: time.Date(2023, time.March, 8, 12, 0, 0, 0, time.UTC),
+		},
+		{
+			Whilst:   Whilst{Days: 30},
+			From:     jan31,
+			Policy:   MonthEndError,
+			Expected: time.Date(2023, time.March, 2, 12, 0, 0, 0, time.UTC),
+		},
+		{
+			Whilst:   Whilst{Months: 1},
+			From:     jan31,
+			Policy:   MonthEnd(-1),
+			Expected: time.Date(2023, time.March, 3, 12, 0, 0, 0, time.UTC),
+		},
+	}
+
+	for _, item := range expected {
+		when, err := item.Whilst.WhenWith(item.From, item.Policy)
+		require.ErrorIs(t, err, item.Err, "whilst: %v, policy: %v", item.Whilst, item.Policy)
+		require.Equal(t, item.Expected, when, "whilst: %v, policy: %v", item.Whilst, item.Policy)
+	}
+}
+
+func TestWhenWithLocation(t *testing.T) {
+	location, err := time.LoadLocation("America/New_York")
+	require.NoError(t, err)
+
+	from := time.Date(2023, time.January, 31, 12, 0, 0, 0, location)
+
+	when, err := Whilst{Months: 1}.WhenWith(from, MonthEndClamp)
+	require.NoError(t, err)
+	require.Equal(t, time.Date(2023, time.February, 28, 12, 0, 0, 0, location), when)
+	require.Equal(t, location, when.Location())
+}
+
+func TestDurationWith(t *testing.T) {
+	from := time.Date(2023, time.January, 31, 0, 0, 0, 0, time.UTC)
+
+	duration, err := Whilst{Months: 1}.DurationWith(from, MonthEndNormalize)
+	require.NoError(t, err)
+	require.Equal(t, Whilst{Months: 1}.Duration(from), duration)
+	require.Equal(t, 31*24*time.Hour, duration)
+
+	duration, err = Whilst{Months: 1}.DurationWith(from, MonthEndClamp)
+	require.NoError(t, err)
+	require.Equal(t, 28*24*time.Hour, duration)
+
+	duration, err = Whilst{Months: 1}.DurationWith(from, MonthEndError)
+	require.ErrorIs(t, err, ErrDayNonexistent)
+	require.Equal(t, time.Duration(0), duration)
+}
+
+func FuzzWhenWith(f *testing.F) {
+	f.Add(int64(1675123200), int64(0), uint16(0), uint16(1), uint16(0), false)
+	f.Add(int64(1680220800), int64(1), uint16(1), uint16(1), uint16(1), true)
+
+	f.Fuzz(
+		func(t *testing.T, fromUnix int64, nano int64, days, months, years uint16, negative bool) {
+			const limit = 1 << 40
+
+			if fromUnix > limit || fromUnix < -limit {
+				return
+			}
+
+			from := time.Unix(fromUnix, 0).UTC()
+
+			whl := Whilst{
+				Nano:     time.Duration(nano),
+				Days:     days,
+				Months:   months,
+				Years:    years,
+				Negative: negative,
+			}
+
+			normalized, err := whl.WhenWith(from, MonthEndNormalize)
+			require.NoError(t, err)
+			require.Equal(t, whl.When(from), normalized)
+
+			clamped, err := whl.WhenWith(from, MonthEndClamp)
+			require.NoError(t, err)
+			require.False(t, clamped.After(normalized) && !whl.IsNegative())
+
+			strict, err := whl.WhenWith(from, MonthEndError)
+			if err != nil {
+				require.ErrorIs(t, err, ErrDayNonexistent)
+				return
+			}
+
+			require.Equal(t, normalized, strict)
+			require.Equal(t, normalized, clamped)
+		},
+	)
+}
@@ -1,7 +1,10 @@
 package whilst
 
 import (
+	"errors"
+	"strings"
 	"time"
+	"unicode/utf8"
 
 	"github.com/akramarenkov/whilst/internal/ascii"
 	"github.com/akramarenkov/whilst/internal/consts"
@@ -12,18 +15,31 @@ import (
 
 // Parsing context.
 type parser struct {
-	whl *Whilst
+	whl Whilst
 
-	input string
+	origin string
+	input  string
 
 	foundNum bool
 	foundDot bool
 
-	idUnit int
+	idNumber int
+	idUnit   int
 
 	integer  uint64
-	fraction int64
-	scale    float64
+	fraction uint64
+	digits   int
+	dropped  bool
+
+	spaced     bool
+	foundSpace bool
+
+	// Whether units are looked up in accordance with the parsing options rather
+	// than only among the default ones
+	named    bool
+	caseless bool
+	names    map[string]Unit
+	units    *Units
 }
 
 // Parses the input string.
@@ -32,13 +48,49 @@ type parser struct {
 // ^\s*[-+]?\s*([0-9]*(\.[0-9]*)?[a-z]+\s*)+$.
 func parse(input string, whl *Whilst) error {
 	prs := &parser{
-		input: input,
-		whl:   whl,
+		origin: input,
+		input:  input,
 	}
 
 	prs.reset()
 
-	return prs.parse()
+	if err := prs.parse(); err != nil {
+		return err
+	}
+
+	*whl = prs.whl
+
+	return nil
+}
+
+// Parses the input string taking into account the options.
+func parseWith(input string, whl *Whilst, opts ParseOptions) error {
+	prs := &parser{
+		origin:   input,
+		input:    input,
+		spaced:   opts.SpaceBeforeUnit,
+		named:    opts.CaseInsensitive || opts.LongUnits || opts.Units != nil,
+		caseless: opts.CaseInsensitive,
+		units:    opts.Units,
+	}
+
+	if opts.LongUnits {
+		if opts.Locale == nil {
+			opts.Locale = English()
+		}
+
+		prs.names = localeUnits(opts.Locale, opts.CaseInsensitive)
+	}
+
+	prs.reset()
+
+	if err := prs.parse(); err != nil {
+		return err
+	}
+
+	*whl = prs.whl
+
+	return nil
 }
 
 func (prs *parser) parse() error {
@@ -77,7 +129,8 @@ func (prs *parser) parse() error {
 		}
 
 		if !prs.foundNum {
-			return ErrNumberUnspecified
+			_, size := utf8.DecodeRuneInString(prs.input[id:])
+			return prs.newError(ErrNumberUnspecified, id, id+size)
 		}
 
 		if prs.idUnit == -1 {
@@ -102,7 +155,7 @@ func (prs *parser) begin() error {
 	for id, char := range []byte(prs.input) {
 		if char == charMinus || char == charPlus {
 			if foundSign {
-				return ErrCharSignAgain
+				return prs.newError(ErrCharSignAgain, id, id+1)
 			}
 
 			foundSign = true
@@ -121,10 +174,12 @@ func (prs *parser) begin() error {
 			continue
 		}
 
-		return ErrUnexpectedChar
+		_, size := utf8.DecodeRuneInString(prs.input[id:])
+
+		return prs.newError(ErrUnexpectedChar, id, id+size)
 	}
 
-	return ErrInputEmpty
+	return prs.newError(ErrInputEmpty, len(prs.input), len(prs.input))
 }
 
 func (prs *parser) onDigit(id int) error {
@@ -132,7 +187,17 @@ func (prs *parser) onDigit(id int) error {
 
 	if !prs.foundNum {
 		prs.foundNum = true
-		return prs.incInteger(char)
+		prs.idNumber = id
+
+		if err := prs.incInteger(char); err != nil {
+			return prs.newError(err, id, id+1)
+		}
+
+		return nil
+	}
+
+	if prs.foundSpace && prs.idUnit == -1 {
+		return prs.newError(ErrUnitUnspecified, prs.idNumber, id)
 	}
 
 	if prs.idUnit != -1 {
@@ -142,10 +207,15 @@ func (prs *parser) onDigit(id int) error {
 
 		prs.foundNum = true
 		prs.foundDot = false
+		prs.idNumber = id
 
 		prs.reset()
 
-		return prs.incInteger(char)
+		if err := prs.incInteger(char); err != nil {
+			return prs.newError(err, id, id+1)
+		}
+
+		return nil
 	}
 
 	if prs.foundDot {
@@ -153,17 +223,26 @@ func (prs *parser) onDigit(id int) error {
 		return nil
 	}
 
-	return prs.incInteger(char)
+	if err := prs.incInteger(char); err != nil {
+		return prs.newError(err, prs.idNumber, id+1)
+	}
+
+	return nil
 }
 
 func (prs *parser) onDot(id int) error {
 	if !prs.foundNum {
 		prs.foundNum = true
 		prs.foundDot = true
+		prs.idNumber = id
 
 		return nil
 	}
 
+	if prs.foundSpace && prs.idUnit == -1 {
+		return prs.newError(ErrUnitUnspecified, prs.idNumber, id)
+	}
+
 	if prs.idUnit != -1 {
 		if err := prs.addValue(id); err != nil {
 			return err
@@ -171,6 +250,7 @@ func (prs *parser) onDot(id int) error {
 
 		prs.foundNum = true
 		prs.foundDot = true
+		prs.idNumber = id
 
 		prs.reset()
 
@@ -178,7 +258,7 @@ func (prs *parser) onDot(id int) error {
 	}
 
 	if prs.foundDot {
-		return ErrCharDotAgain
+		return prs.newError(ErrCharDotAgain, id, id+1)
 	}
 
 	prs.foundDot = true
@@ -192,7 +272,12 @@ func (prs *parser) onSpace(id int) error {
 	}
 
 	if prs.idUnit == -1 {
-		return ErrUnitUnspecified
+		if prs.spaced {
+			prs.foundSpace = true
+			return nil
+		}
+
+		return prs.newError(ErrUnitUnspecified, prs.idNumber, id)
 	}
 
 	if err := prs.addValue(id); err != nil {
@@ -213,7 +298,7 @@ func (prs *parser) end() error {
 	}
 
 	if prs.idUnit == -1 {
-		return ErrUnitUnspecified
+		return prs.newError(ErrUnitUnspecified, prs.idNumber, len(prs.input))
 	}
 
 	return prs.addValue(len(prs.input))
@@ -237,21 +322,29 @@ func (prs *parser) incInteger(char byte) error {
 	return nil
 }
 
+// Digits of a fraction that do not fit into the accumulator are discarded along with
+// all subsequent ones, just like time.ParseDuration does.
 func (prs *parser) incFraction(char byte) {
-	digit := ascii.ByteToDigit[int64](char)
+	const limit = 1 << 63
 
-	shifted, err := credible.MulBy10(prs.fraction)
-	if err != nil {
+	if prs.dropped {
 		return
 	}
 
-	increased, err := credible.Add(shifted, digit)
-	if err != nil {
+	if prs.fraction > (limit-1)/consts.DecimalBase {
+		prs.dropped = true
+		return
+	}
+
+	increased := prs.fraction*consts.DecimalBase + ascii.ByteToDigit[uint64](char)
+
+	if increased > limit {
+		prs.dropped = true
 		return
 	}
 
 	prs.fraction = increased
-	prs.scale *= consts.DecimalBase
+	prs.digits++
 }
 
 func (prs *parser) reset() {
@@ -259,104 +352,147 @@ func (prs *parser) reset() {
 
 	prs.integer = 0
 	prs.fraction = 0
-	prs.scale = 1
+	prs.digits = 0
+	prs.dropped = false
+
+	prs.foundSpace = false
 }
 
+// Adds accumulated value to the component of the duration corresponding to the unit
+// that ends at the specified position.
 func (prs *parser) addValue(id int) error {
-	whole := prs.integer
-	dimension := time.Nanosecond
 	unit := prs.input[prs.idUnit:id]
 
-	switch unit {
-	case unitYear:
-		if prs.fraction != 0 {
-			return ErrOnlyInteger
-		}
+	var err error
 
-		increased, err := credible.AddU64ToU16(prs.whl.Years, prs.integer)
-		if err != nil {
-			return err
-		}
-
-		prs.whl.Years = increased
+	if prs.named {
+		err = prs.addNamed(unit)
+	} else {
+		err = prs.addUnit(unit)
+	}
 
+	if err == nil {
 		return nil
-	case unitMonth:
-		if prs.fraction != 0 {
-			return ErrOnlyInteger
-		}
+	}
 
-		increased, err := credible.AddU64ToU16(prs.whl.Months, prs.integer)
-		if err != nil {
-			return err
-		}
+	if errors.Is(err, ErrUnexpectedUnit) {
+		return prs.newError(err, prs.idUnit, id)
+	}
 
-		prs.whl.Months = increased
+	return prs.newError(err, prs.idNumber, id)
+}
 
-		return nil
-	case unitDay:
-		if prs.fraction != 0 {
-			return ErrOnlyInteger
-		}
+// Adds accumulated value to the component of the duration corresponding to the unit
+// specified in accordance with the parsing options.
+func (prs *parser) addNamed(unit string) error {
+	if prs.caseless {
+		unit = strings.ToLower(unit)
+	}
 
-		increased, err := credible.AddU64ToU16(prs.whl.Days, prs.integer)
-		if err != nil {
-			return err
-		}
+	if long, found := prs.names[unit]; found {
+		return prs.addComponent(UnitDef{Component: long, Factor: 1})
+	}
 
-		prs.whl.Days = increased
+	if prs.units == nil {
+		return prs.addUnit(unit)
+	}
 
-		return nil
-	case unitHour:
-		dimension = time.Hour
+	def, found := prs.units.defs[unit]
+	if !found {
+		return ErrUnexpectedUnit
+	}
 
-		multiplied, err := credible.MulByHour(whole)
-		if err != nil {
-			return err
-		}
+	return prs.addComponent(def)
+}
 
-		whole = multiplied
-	case unitMinute:
-		dimension = time.Minute
+// Adds accumulated value, measured in the unit with the specified definition, to
+// the corresponding component of the duration.
+func (prs *parser) addComponent(def UnitDef) error {
+	if !isCalendar(def.Component) {
+		dimension := humanDimensions[def.Component] * def.Factor
+		return prs.addNano(time.Duration(dimension))
+	}
 
-		multiplied, err := credible.MulByMinute(whole)
-		if err != nil {
-			return err
-		}
+	if prs.fraction != 0 {
+		return ErrOnlyInteger
+	}
 
-		whole = multiplied
-	case unitSecond:
-		dimension = time.Second
+	integer, err := safe.MulU(prs.integer, def.Factor)
+	if err != nil {
+		return err
+	}
 
-		multiplied, err := credible.MulBySecond(whole)
-		if err != nil {
-			return err
-		}
+	prs.integer = integer
 
-		whole = multiplied
-	case unitMillisecond:
-		dimension = time.Millisecond
+	switch def.Component {
+	case UnitYear:
+		return prs.addCalendar(&prs.whl.Years)
+	case UnitMonth:
+		return prs.addCalendar(&prs.whl.Months)
+	case UnitWeek:
+		return prs.addCalendar(&prs.whl.Weeks)
+	}
 
-		multiplied, err := credible.MulByMillisecond(whole)
-		if err != nil {
-			return err
-		}
+	return prs.addCalendar(&prs.whl.Days)
+}
+
+func (prs *parser) addUnit(unit string) error {
+	var dimension time.Duration
 
-		whole = multiplied
+	switch unit {
+	case unitYear:
+		return prs.addCalendar(&prs.whl.Years)
+	case unitMonth:
+		return prs.addCalendar(&prs.whl.Months)
+	case unitWeek:
+		return prs.addCalendar(&prs.whl.Weeks)
+	case unitDay:
+		return prs.addCalendar(&prs.whl.Days)
+	case unitHour:
+		dimension = time.Hour
+	case unitMinute:
+		dimension = time.Minute
+	case unitSecond:
+		dimension = time.Second
+	case unitMillisecond:
+		dimension = time.Millisecond
 	case unitMicrosecond, unitMicrosecondA1, unitMicrosecondA2:
 		dimension = time.Microsecond
-
-		multiplied, err := credible.MulByMicrosecond(whole)
-		if err != nil {
-			return err
-		}
-
-		whole = multiplied
 	case unitNanosecond:
+		dimension = time.Nanosecond
 	default:
 		return ErrUnexpectedUnit
 	}
 
+	return prs.addNano(dimension)
+}
+
+// Adds accumulated value to the one of the days, weeks, months or years.
+func (prs *parser) addCalendar(value *uint16) error {
+	if prs.fraction != 0 {
+		return ErrOnlyInteger
+	}
+
+	increased, err := credible.AddU64ToU16(*value, prs.integer)
+	if err != nil {
+		return err
+	}
+
+	*value = increased
+
+	return nil
+}
+
+// Adds accumulated value, measured in the specified dimension, to the nanoseconds.
+//
+// Fractional part is converted to nanoseconds in the same way as time.ParseDuration
+// does and is truncated to an integer number of nanoseconds.
+func (prs *parser) addNano(dimension time.Duration) error {
+	whole, err := mulByDimension(prs.integer, dimension)
+	if err != nil {
+		return err
+	}
+
 	duration, err := credible.AddU64ToS64(int64(prs.whl.Nano), whole, prs.whl.Negative)
 	if err != nil {
 		return err
@@ -367,9 +503,9 @@ func (prs *parser) addValue(id int) error {
 		return nil
 	}
 
-	converted := float64(prs.fraction) * (float64(dimension) / float64(prs.scale))
+	converted := credible.MulFraction(prs.fraction, prs.digits, uint64(dimension))
 
-	duration, err = credible.AddU64ToS64(duration, uint64(converted), prs.whl.Negative)
+	duration, err = credible.AddU64ToS64(duration, converted, prs.whl.Negative)
 	if err != nil {
 		return err
 	}
@@ -378,3 +514,57 @@ func (prs *parser) addValue(id int) error {
 
 	return nil
 }
+
+// Adds accumulated value to the component of the duration corresponding to the rank.
+func (prs *parser) addRank(rank int) error {
+	switch rank {
+	case rankYear:
+		return prs.addCalendar(&prs.whl.Years)
+	case rankMonth:
+		return prs.addCalendar(&prs.whl.Months)
+	case rankWeek:
+		return prs.addCalendar(&prs.whl.Weeks)
+	case rankDay:
+		return prs.addCalendar(&prs.whl.Days)
+	case rankHour:
+		return prs.addNano(time.Hour)
+	case rankMinute:
+		return prs.addNano(time.Minute)
+	}
+
+	return prs.addNano(time.Second)
+}
+
+func mulByDimension(number uint64, dimension time.Duration) (uint64, error) {
+	switch dimension {
+	case time.Hour:
+		return credible.MulByHour(number)
+	case time.Minute:
+		return credible.MulByMinute(number)
+	case time.Second:
+		return credible.MulBySecond(number)
+	case time.Millisecond:
+		return credible.MulByMillisecond(number)
+	case time.Microsecond:
+		return credible.MulByMicrosecond(number)
+	case time.Nanosecond:
+		return number, nil
+	}
+
+	return safe.MulU(number, uint64(dimension))
+}
+
+// Wraps the error into the ParseError with the token located between the specified
+// positions of the input string.
+func (prs *parser) newError(err error, begin, end int) error {
+	shift := len(prs.origin) - len(prs.input)
+
+	parseErr := &ParseError{
+		Input:  prs.origin,
+		Offset: shift + begin,
+		Token:  prs.input[begin:end],
+		Err:    err,
+	}
+
+	return parseErr
+}
@@ -0,0 +1,807 @@
+package whilst
+
+import (
+	"errors"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/akramarenkov/whilst/internal/ascii"
+	"github.com/akramarenkov/whilst/internal/consts"
+
+	"github.com/akramarenkov/intspec"
+	"github.com/akramarenkov/safe"
+)
+
+// Style of a PostgreSQL interval representation, corresponds to the values of
+// the IntervalStyle setting.
+type IntervalStyle int
+
+const (
+	// E.g. -1 years -2 mons -3 days -04:05:06.789.
+	IntervalStylePostgres IntervalStyle = iota
+	// E.g. @ 1 year 2 mons 3 days 4 hours 5 mins 6.789 secs ago.
+	IntervalStylePostgresVerbose
+	// E.g. -1-2 or -3 4:05:06.789 or +1-2 +3 +4:05:06.789.
+	IntervalStyleSQLStandard
+	// E.g. P-1Y-2M-3DT-4H-5M-6.789S.
+	IntervalStyleISO8601
+)
+
+const (
+	pgAgo          = "ago"
+	pgVerbose      = "@"
+	pgZero         = "0"
+	pgZeroTime     = "00:00:00"
+	pgYearMonth    = '-'
+	pgTimeDivider  = ':'
+	pgPlural       = 's'
+	pgUnitYear     = "year"
+	pgUnitMonth    = "mon"
+	pgUnitMonthA1  = "month"
+	pgUnitWeek     = "week"
+	pgUnitDay      = "day"
+	pgUnitHour     = "hour"
+	pgUnitMinute   = "min"
+	pgUnitMinuteA1 = "minute"
+	pgUnitSecond   = "sec"
+	pgUnitSecondA1 = "second"
+	pgTwoDigits    = 10
+)
+
+// Parsing context of the PostgreSQL interval representation.
+type pgParser struct {
+	prs parser
+
+	input   string
+	fields  []string
+	offsets []int
+
+	// Index of the field being parsed, equal to the number of fields if the input
+	// string ended unexpectedly
+	field int
+
+	// Sign of non-zero components found so far: zero if none was found,
+	// negative or positive otherwise
+	sign int
+}
+
+// Parses a PostgreSQL interval representation of the duration.
+//
+// Output of all IntervalStyle settings is accepted:
+//   - postgres         - 1 year 2 mons 3 days 04:05:06.789
+//   - postgres_verbose - @ 1 year 2 mons 3 days 4 hours 5 mins 6.789 secs ago
+//   - sql_standard     - 1-2 or 3 4:05:06.789 or +1-2 +3 +4:05:06.789
+//   - iso_8601         - P1Y2M3DT4H5M6.789S
+//
+// Units may be specified in singular or plural form, full names of month, minute
+// and second are also accepted. Hours, minutes and seconds may also be specified
+// in the form [-+]H:MM[:SS[.FFFFFF]].
+//
+// Each component of a PostgreSQL interval may have its own sign, however all
+// non-zero components must have the same sign, otherwise [ErrMixedSigns] is
+// returned. A value of days, months and years can only be an integer and cannot
+// be greater than 65535 for each, years are not converted to months and vice versa.
+// Weeks are converted to days, as PostgreSQL does.
+//
+// In case of an error, [*ParseError] is returned.
+func ParsePostgresInterval(input string) (Whilst, error) {
+	whl := Whilst{}
+
+	if err := parsePostgresInterval(input, &whl); err != nil {
+		return Whilst{}, err
+	}
+
+	return whl, nil
+}
+
+func parsePostgresInterval(input string, whl *Whilst) error {
+	fields := strings.Fields(input)
+
+	if len(fields) == 0 {
+		parseErr := &ParseError{
+			Input:  input,
+			Offset: len(input),
+			Err:    ErrInputEmpty,
+		}
+
+		return parseErr
+	}
+
+	offsets := fieldOffsets(input, fields)
+
+	if len(fields) == 1 && isPostgresISO8601(fields[0]) {
+		if err := parsePostgresISO8601(fields[0], whl); err != nil {
+			var parseErr *ParseError
+
+			if errors.As(err, &parseErr) {
+				parseErr.Input = input
+				parseErr.Offset += offsets[0]
+			}
+
+			return err
+		}
+	} else {
+		pgp := &pgParser{
+			input:   input,
+			fields:  fields,
+			offsets: offsets,
+		}
+
+		pgp.prs.reset()
+
+		if err := pgp.parse(); err != nil {
+			return pgp.newError(err)
+		}
+
+		*whl = pgp.prs.whl
+
+		whl.Negative = pgp.sign < 0
+
+		if whl.IsZero() {
+			whl.Negative = false
+		}
+	}
+
+	days := whl.pgDays()
+
+	if days > intspec.MaxUint16 {
+		last := len(fields) - 1
+
+		parseErr := &ParseError{
+			Input:  input,
+			Offset: offsets[0],
+			Token:  input[offsets[0] : offsets[last]+len(fields[last])],
+			Err:    safe.ErrOverflow,
+		}
+
+		return parseErr
+	}
+
+	whl.Days = uint16(days)
+	whl.Weeks = 0
+
+	return nil
+}
+
+// Returns byte offsets in the input string of the fields obtained by splitting it
+// around white space.
+func fieldOffsets(input string, fields []string) []int {
+	offsets := make([]int, len(fields))
+	shift := 0
+
+	for id, field := range fields {
+		offsets[id] = shift + strings.Index(input[shift:], field)
+		shift = offsets[id] + len(field)
+	}
+
+	return offsets
+}
+
+// Wraps the error into the ParseError with the token, which is the field being
+// parsed.
+func (pgp *pgParser) newError(err error) error {
+	return newFieldError(err, pgp.input, pgp.fields, pgp.offsets, pgp.field)
+}
+
+// Wraps the error into the ParseError with the token, which is the field with
+// the specified index. If there is no such field, then the input string is
+// considered to have ended unexpectedly.
+func newFieldError(err error, input string, fields []string, offsets []int, field int) error {
+	parseErr := &ParseError{
+		Input:  input,
+		Offset: len(input),
+		Err:    err,
+	}
+
+	if field < len(fields) {
+		parseErr.Offset = offsets[field]
+		parseErr.Token = fields[field]
+	}
+
+	return parseErr
+}
+
+func (pgp *pgParser) parse() error {
+	if pgp.fields[0] == pgVerbose {
+		return pgp.parseVerbose()
+	}
+
+	for _, field := range pgp.fields {
+		if strings.IndexFunc(field, isLetter) != -1 {
+			return pgp.parseUnits(pgp.fields, 0, false)
+		}
+	}
+
+	return pgp.parseSQLStandard()
+}
+
+func (pgp *pgParser) parseVerbose() error {
+	fields := pgp.fields
+
+	ago := len(fields) > 1 && fields[len(fields)-1] == pgAgo
+
+	if ago {
+		fields = fields[:len(fields)-1]
+	}
+
+	if len(fields) == 2 && fields[1] == pgZero {
+		return nil
+	}
+
+	if len(fields) == 1 {
+		pgp.field = len(pgp.fields)
+		return ErrNumberUnspecified
+	}
+
+	return pgp.parseUnits(fields, 1, ago)
+}
+
+// Parses the fields starting from the specified one, the fields may be truncated
+// relative to the fields of the parser.
+func (pgp *pgParser) parseUnits(fields []string, begin int, ago bool) error {
+	for id := begin; id < len(fields); id++ {
+		pgp.field = id
+
+		number, negative := cutSign(fields[id])
+
+		if strings.IndexByte(number, pgTimeDivider) != -1 {
+			if err := pgp.addTime(number, negative != ago); err != nil {
+				return err
+			}
+
+			continue
+		}
+
+		if id+1 == len(fields) {
+			return ErrUnitUnspecified
+		}
+
+		rank, err := pgUnitToRank(fields[id+1])
+		if err != nil {
+			pgp.field = id + 1
+			return err
+		}
+
+		if err := pgp.add(rank, number, negative != ago); err != nil {
+			return err
+		}
+
+		id++
+	}
+
+	return nil
+}
+
+func (pgp *pgParser) parseSQLStandard() error {
+	fields := pgp.fields
+
+	// If only the first field has a sign, then it applies to all fields
+	_, common := cutSign(fields[0])
+
+	for _, field := range fields[1:] {
+		if field[0] == charMinus || field[0] == charPlus {
+			common = false
+			break
+		}
+	}
+
+	for id, field := range fields {
+		pgp.field = id
+
+		number, negative := cutSign(field)
+
+		negative = negative || common
+
+		if strings.IndexByte(number, pgTimeDivider) != -1 {
+			if err := pgp.addTime(number, negative); err != nil {
+				return err
+			}
+
+			continue
+		}
+
+		if years, months, found := strings.Cut(number, string(pgYearMonth)); found {
+			if err := pgp.add(rankYear, years, negative); err != nil {
+				return err
+			}
+
+			if err := pgp.add(rankMonth, months, negative); err != nil {
+				return err
+			}
+
+			continue
+		}
+
+		// Lone number without a unit means seconds
+		rank := rankSecond
+
+		if len(fields) != 1 {
+			rank = rankDay
+		}
+
+		if err := pgp.add(rank, number, negative); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func (pgp *pgParser) addTime(field string, negative bool) error {
+	hours, remainder, _ := strings.Cut(field, string(pgTimeDivider))
+	minutes, seconds, found := strings.Cut(remainder, string(pgTimeDivider))
+
+	if err := pgp.add(rankHour, hours, negative); err != nil {
+		return err
+	}
+
+	if err := pgp.add(rankMinute, minutes, negative); err != nil {
+		return err
+	}
+
+	if !found {
+		return nil
+	}
+
+	return pgp.add(rankSecond, seconds, negative)
+}
+
+func (pgp *pgParser) add(rank int, number string, negative bool) error {
+	if err := pgp.prs.loadNumber(number); err != nil {
+		return err
+	}
+
+	if pgp.prs.integer != 0 || pgp.prs.fraction != 0 {
+		sign := 1
+
+		if negative {
+			sign = -1
+		}
+
+		if pgp.sign != 0 && pgp.sign != sign {
+			return ErrMixedSigns
+		}
+
+		pgp.sign = sign
+	}
+
+	pgp.prs.whl.Negative = negative
+
+	return pgp.prs.addRank(rank)
+}
+
+// Accumulates a decimal number, possibly with a fractional part.
+func (prs *parser) loadNumber(number string) error {
+	prs.reset()
+
+	foundNum := false
+	foundDot := false
+
+	for _, char := range []byte(number) {
+		if ascii.IsDigit(char) {
+			foundNum = true
+
+			if foundDot {
+				prs.incFraction(char)
+				continue
+			}
+
+			if err := prs.incInteger(char); err != nil {
+				return err
+			}
+
+			continue
+		}
+
+		if char != charDot {
+			return ErrUnexpectedChar
+		}
+
+		if foundDot {
+			return ErrCharDotAgain
+		}
+
+		foundDot = true
+	}
+
+	if !foundNum {
+		return ErrNumberUnspecified
+	}
+
+	return nil
+}
+
+func pgUnitToRank(unit string) (int, error) {
+	switch strings.TrimSuffix(unit, string(pgPlural)) {
+	case pgUnitYear:
+		return rankYear, nil
+	case pgUnitMonth, pgUnitMonthA1:
+		return rankMonth, nil
+	case pgUnitWeek:
+		return rankWeek, nil
+	case pgUnitDay:
+		return rankDay, nil
+	case pgUnitHour:
+		return rankHour, nil
+	case pgUnitMinute, pgUnitMinuteA1:
+		return rankMinute, nil
+	case pgUnitSecond, pgUnitSecondA1:
+		return rankSecond, nil
+	}
+
+	return rankNone, ErrUnexpectedUnit
+}
+
+func cutSign(field string) (string, bool) {
+	if field == "" {
+		return field, false
+	}
+
+	switch field[0] {
+	case charMinus:
+		return field[1:], true
+	case charPlus:
+		return field[1:], false
+	}
+
+	return field, false
+}
+
+func isLetter(char rune) bool {
+	return (char >= 'a' && char <= 'z') || (char >= 'A' && char <= 'Z')
+}
+
+func isPostgresISO8601(field string) bool {
+	field, _ = cutSign(field)
+	return field != "" && field[0] == designatorPeriod
+}
+
+// PostgreSQL marks each negative component of the ISO 8601 representation with its
+// own sign, so signs are removed and, if all non-zero components are negative,
+// a common sign is specified at a beginning of a string. Positions of the ParseError
+// are mapped back to the input string.
+func parsePostgresISO8601(input string, whl *Whilst) error {
+	if input[0] != designatorPeriod {
+		return parseISO8601(input, whl)
+	}
+
+	stripped := make([]byte, 1, len(input)+1)
+	stripped[0] = charMinus
+
+	// Positions of characters of the stripped string in the input string, the common
+	// sign corresponds to the period designator
+	positions := make([]int, 1, len(input)+1)
+
+	sign := 0
+	negative := false
+	zero := true
+	begin := 0
+
+	for id, char := range []byte(input) {
+		switch {
+		case char == charMinus:
+			negative = true
+			continue
+		case ascii.IsDigit(char):
+			zero = zero && char == '0'
+		case char == charDot || char == charComma:
+		case char == designatorPeriod || char == designatorTime:
+			begin = id + 1
+		default:
+			if !zero {
+				current := 1
+
+				if negative {
+					current = -1
+				}
+
+				if sign != 0 && sign != current {
+					parseErr := &ParseError{
+						Input:  input,
+						Offset: begin,
+						Token:  input[begin : id+1],
+						Err:    ErrMixedSigns,
+					}
+
+					return parseErr
+				}
+
+				sign = current
+			}
+
+			negative = false
+			zero = true
+			begin = id + 1
+		}
+
+		stripped = append(stripped, char)
+		positions = append(positions, id)
+	}
+
+	if sign >= 0 {
+		stripped = stripped[1:]
+		positions = positions[1:]
+	}
+
+	err := parseISO8601(string(stripped), whl)
+
+	var parseErr *ParseError
+
+	if errors.As(err, &parseErr) {
+		begin := len(input)
+
+		if parseErr.Offset < len(positions) {
+			begin = positions[parseErr.Offset]
+		}
+
+		end := begin
+
+		if parseErr.Token != "" {
+			end = positions[parseErr.Offset+len(parseErr.Token)-1] + 1
+		}
+
+		parseErr.Input = input
+		parseErr.Offset = begin
+		parseErr.Token = input[begin:end]
+	}
+
+	return err
+}
+
+// Returns a PostgreSQL interval representation of the duration in the specified
+// style. Unknown style is treated as [IntervalStylePostgres].
+//
+// Fraction of a second is represented with nanosecond precision, while PostgreSQL
+// itself stores intervals with microsecond precision. Years are not converted to
+// months and vice versa, except for [IntervalStyleSQLStandard] which requires that
+// the number of months be less than twelve. Weeks are converted to days.
+func FormatPostgresInterval(whl Whilst, style IntervalStyle) string {
+	output := make([]byte, 0, len(formatMaximumPostgres))
+
+	switch style {
+	case IntervalStylePostgresVerbose:
+		output = whl.appendPostgresVerbose(output)
+	case IntervalStyleSQLStandard:
+		output = whl.appendSQLStandard(output)
+	case IntervalStyleISO8601:
+		output = whl.appendPostgresISO8601(output)
+	default:
+		output = whl.appendPostgres(output)
+	}
+
+	return string(output)
+}
+
+func (whl Whilst) appendPostgres(output []byte) []byte {
+	negative := whl.Negative || whl.Nano < 0
+
+	output = appendPostgresPart(output, uint64(whl.Years), pgUnitYear, negative)
+	output = appendPostgresPart(output, uint64(whl.Months), pgUnitMonth, negative)
+	output = appendPostgresPart(output, whl.pgDays(), pgUnitDay, negative)
+
+	if whl.Nano == 0 {
+		if len(output) == 0 {
+			output = append(output, pgZeroTime...)
+		}
+
+		return output
+	}
+
+	if len(output) != 0 {
+		output = append(output, ' ')
+	}
+
+	if negative {
+		output = append(output, charMinus)
+	}
+
+	hours, minutes, seconds, fraction := splitNano(safe.Abs(whl.Nano))
+
+	output = appendTwoDigits(output, hours)
+	output = append(output, pgTimeDivider)
+	output = appendTwoDigits(output, minutes)
+	output = append(output, pgTimeDivider)
+	output = appendTwoDigits(output, seconds)
+	output = appendFraction(output, fraction)
+
+	return output
+}
+
+// Negative values are always in plural form, just like in PostgreSQL.
+func appendPostgresPart(output []byte, value uint64, unit string, negative bool) []byte {
+	if value == 0 {
+		return output
+	}
+
+	if len(output) != 0 {
+		output = append(output, ' ')
+	}
+
+	if negative {
+		output = append(output, charMinus)
+	}
+
+	output = strconv.AppendUint(output, value, consts.DecimalBase)
+	output = append(output, ' ')
+	output = append(output, unit...)
+
+	if negative || value != 1 {
+		output = append(output, pgPlural)
+	}
+
+	return output
+}
+
+func (whl Whilst) appendPostgresVerbose(output []byte) []byte {
+	hours, minutes, seconds, fraction := splitNano(safe.Abs(whl.Nano))
+
+	output = append(output, pgVerbose...)
+
+	output = appendVerbosePart(output, uint64(whl.Years), 0, pgUnitYear)
+	output = appendVerbosePart(output, uint64(whl.Months), 0, pgUnitMonth)
+	output = appendVerbosePart(output, whl.pgDays(), 0, pgUnitDay)
+	output = appendVerbosePart(output, hours, 0, pgUnitHour)
+	output = appendVerbosePart(output, minutes, 0, pgUnitMinute)
+	output = appendVerbosePart(output, seconds, fraction, pgUnitSecond)
+
+	if whl.IsZero() {
+		output = append(output, ' ')
+		return append(output, pgZero...)
+	}
+
+	if whl.Negative || whl.Nano < 0 {
+		output = append(output, ' ')
+		output = append(output, pgAgo...)
+	}
+
+	return output
+}
+
+func appendVerbosePart(output []byte, value, fraction uint64, unit string) []byte {
+	if value == 0 && fraction == 0 {
+		return output
+	}
+
+	output = append(output, ' ')
+	output = strconv.AppendUint(output, value, consts.DecimalBase)
+	output = appendFraction(output, fraction)
+	output = append(output, ' ')
+	output = append(output, unit...)
+
+	if value != 1 || fraction != 0 {
+		output = append(output, pgPlural)
+	}
+
+	return output
+}
+
+func (whl Whilst) appendSQLStandard(output []byte) []byte {
+	negative := whl.Negative || whl.Nano < 0
+
+	months := uint64(whl.Years)*monthsInYear + uint64(whl.Months)
+
+	hasYearMonth := months != 0
+	hasDayTime := whl.pgDays() != 0 || whl.Nano != 0
+
+	switch {
+	case !hasYearMonth && !hasDayTime:
+		return append(output, pgZero...)
+	case hasYearMonth && hasDayTime:
+		// Signs are specified for each field, otherwise a sign of the first field
+		// would apply to all of them
+		sign := byte(charPlus)
+
+		if negative {
+			sign = charMinus
+		}
+
+		output = append(output, sign)
+		output = appendYearMonth(output, months)
+		output = append(output, ' ', sign)
+		output = strconv.AppendUint(output, whl.pgDays(), consts.DecimalBase)
+		output = append(output, ' ', sign)
+
+		return appendSQLStandardTime(output, whl.Nano)
+	}
+
+	if negative {
+		output = append(output, charMinus)
+	}
+
+	if hasYearMonth {
+		return appendYearMonth(output, months)
+	}
+
+	if whl.pgDays() != 0 {
+		output = strconv.AppendUint(output, whl.pgDays(), consts.DecimalBase)
+		output = append(output, ' ')
+	}
+
+	return appendSQLStandardTime(output, whl.Nano)
+}
+
+func appendYearMonth(output []byte, months uint64) []byte {
+	output = strconv.AppendUint(output, months/monthsInYear, consts.DecimalBase)
+	output = append(output, pgYearMonth)
+	output = strconv.AppendUint(output, months%monthsInYear, consts.DecimalBase)
+
+	return output
+}
+
+func appendSQLStandardTime(output []byte, nano time.Duration) []byte {
+	hours, minutes, seconds, fraction := splitNano(safe.Abs(nano))
+
+	output = strconv.AppendUint(output, hours, consts.DecimalBase)
+	output = append(output, pgTimeDivider)
+	output = appendTwoDigits(output, minutes)
+	output = append(output, pgTimeDivider)
+	output = appendTwoDigits(output, seconds)
+	output = appendFraction(output, fraction)
+
+	return output
+}
+
+func (whl Whilst) appendPostgresISO8601(output []byte) []byte {
+	if whl.IsZero() {
+		return append(output, specialZeroISO8601...)
+	}
+
+	negative := whl.Negative || whl.Nano < 0
+
+	output = append(output, designatorPeriod)
+
+	output = appendISO8601Part(output, uint64(whl.Years), 0, designatorYear, negative)
+	output = appendISO8601Part(output, uint64(whl.Months), 0, designatorMonth, negative)
+	output = appendISO8601Part(output, whl.pgDays(), 0, designatorDay, negative)
+
+	if whl.Nano == 0 {
+		return output
+	}
+
+	hours, minutes, seconds, fraction := splitNano(safe.Abs(whl.Nano))
+
+	output = append(output, designatorTime)
+
+	output = appendISO8601Part(output, hours, 0, designatorHour, negative)
+	output = appendISO8601Part(output, minutes, 0, designatorMinute, negative)
+	output = appendISO8601Part(output, seconds, fraction, designatorSecond, negative)
+
+	return output
+}
+
+func appendISO8601Part(
+	output []byte,
+	value uint64,
+	fraction uint64,
+	designator byte,
+	negative bool,
+) []byte {
+	if value == 0 && fraction == 0 {
+		return output
+	}
+
+	if negative {
+		output = append(output, charMinus)
+	}
+
+	output = strconv.AppendUint(output, value, consts.DecimalBase)
+	output = appendFraction(output, fraction)
+	output = append(output, designator)
+
+	return output
+}
+
+func appendTwoDigits(output []byte, value uint64) []byte {
+	if value < pgTwoDigits {
+		output = append(output, '0')
+	}
+
+	return strconv.AppendUint(output, value, consts.DecimalBase)
+}
+
+// Returns the number of days with weeks converted to days, since PostgreSQL does not
+// store weeks separately.
+func (whl Whilst) pgDays() uint64 {
+	return uint64(whl.Weeks)*daysInWeek + uint64(whl.Days)
+}
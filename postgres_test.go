@@ -0,0 +1,236 @@
+package whilst
+
+import (
+	"math"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestParsePostgresInterval(t *testing.T) {
+	positive := Whilst{Years: 1, Months: 2, Days: 3, Nano: 14706789 * time.Millisecond}
+	negative := Whilst{
+		Years:    1,
+		Months:   2,
+		Days:     3,
+		Nano:     -14706789 * time.Millisecond,
+		Negative: true,
+	}
+
+	inputs := map[string]Whilst{
+		"1 year 2 mons 3 days 04:05:06.789":                        positive,
+		"-1 years -2 mons -3 days -04:05:06.789":                   negative,
+		"@ 1 year 2 mons 3 days 4 hours 5 mins 6.789 secs":         positive,
+		"@ 1 year 2 mons 3 days 4 hours 5 mins 6.789 secs ago":     negative,
+		"+1-2 +3 +4:05:06.789":                                     positive,
+		"-1-2 -3 -4:05:06.789":                                     negative,
+		"P1Y2M3DT4H5M6.789S":                                       positive,
+		"P-1Y-2M-3DT-4H-5M-6.789S":                                 negative,
+		"-P1Y2M3DT4H5M6.789S":                                      negative,
+		"  1 year   2 months 3 day 4 hour 5 minutes 6.789 second ": positive,
+		"1 year":                   {Years: 1},
+		"14 mons":                  {Months: 14},
+		"2 weeks":                  {Days: 14},
+		"1 week 3 days":            {Days: 10},
+		"P1W3D":                    {Days: 10},
+		"-1 days":                  {Days: 1, Negative: true},
+		"1 day -00:00:00":          {Days: 1},
+		"-0 years 1 mon":           {Months: 1},
+		"25:00:00":                 {Nano: 25 * time.Hour},
+		"-00:00:01":                {Nano: -time.Second, Negative: true},
+		"00:01":                    {Nano: time.Minute},
+		"00:00:00":                 {},
+		"-00:00:00":                {},
+		"@ 0":                      {},
+		"@ 1 sec":                  {Nano: time.Second},
+		"@ 1 day ago":              {Days: 1, Negative: true},
+		"@ -1 day ago":             {Days: 1},
+		"0":                        {},
+		"5":                        {Nano: 5 * time.Second},
+		"1-2":                      {Years: 1, Months: 2},
+		"-1-2":                     {Years: 1, Months: 2, Negative: true},
+		"3 4:05:06":                {Days: 3, Nano: 4*time.Hour + 5*time.Minute + 6*time.Second},
+		"-3 4:05:06":               {Days: 3, Nano: -4*time.Hour - 5*time.Minute - 6*time.Second, Negative: true},
+		"-0:00:00.000001":          {Nano: -time.Microsecond, Negative: true},
+		"PT0S":                     {},
+		"P-0Y1M":                   {Months: 1},
+		"2562047:47:16.854775807":  {Nano: math.MaxInt64},
+		"-2562047:47:16.854775808": {Nano: math.MinInt64, Negative: true},
+	}
+
+	for input, expected := range inputs {
+		whl, err := ParsePostgresInterval(input)
+		require.NoError(t, err, "input: %v", input)
+		require.Equal(t, expected, whl, "input: %v", input)
+	}
+}
+
+func TestParsePostgresIntervalError(t *testing.T) {
+	inputs := map[string]error{
+		"":                          ErrInputEmpty,
+		"   ":                       ErrInputEmpty,
+		"1 year -2 mons":            ErrMixedSigns,
+		"-1 days +04:05:06":         ErrMixedSigns,
+		"@ 1 year -2 mons ago":      ErrMixedSigns,
+		"+1-2 -3 +4:05:06":          ErrMixedSigns,
+		"P1Y-2M":                    ErrMixedSigns,
+		"P-1YT1H":                   ErrMixedSigns,
+		"1 year 2":                  ErrUnitUnspecified,
+		"1 fortnight":               ErrUnexpectedUnit,
+		"1.5 years":                 ErrOnlyInteger,
+		"1.5 mons":                  ErrOnlyInteger,
+		"1-2.5":                     ErrOnlyInteger,
+		"@":                         ErrNumberUnspecified,
+		"@ ago":                     ErrNumberUnspecified,
+		"year":                      ErrUnitUnspecified,
+		"1 year ago":                ErrUnitUnspecified,
+		"1..5 secs":                 ErrCharDotAgain,
+		"04::06":                    ErrNumberUnspecified,
+		"P1X":                       ErrUnexpectedChar,
+		"65536 years":               nil,
+		"65535 days 1 day":          nil,
+		"9363 weeks 5 days":         nil,
+		"2562048:00:00":             nil,
+		"18446744073709551616 secs": nil,
+	}
+
+	for input, expected := range inputs {
+		whl, err := ParsePostgresInterval(input)
+		require.Error(t, err, "input: %v", input)
+		require.Equal(t, Whilst{}, whl, "input: %v", input)
+
+		if expected != nil {
+			require.ErrorIs(t, err, expected, "input: %v", input)
+		}
+	}
+}
+
+func TestFormatPostgresInterval(t *testing.T) {
+	positive := Whilst{Years: 1, Months: 2, Days: 3, Nano: 14706789 * time.Millisecond}
+	negative := Whilst{
+		Years:    1,
+		Months:   2,
+		Days:     3,
+		Nano:     14706789 * time.Millisecond,
+		Negative: true,
+	}
+
+	expected := []struct {
+		Whilst   Whilst
+		Style    IntervalStyle
+		Expected string
+	}{
+		{positive, IntervalStylePostgres, "1 year 2 mons 3 days 04:05:06.789"},
+		{negative, IntervalStylePostgres, "-1 years -2 mons -3 days -04:05:06.789"},
+		{positive, IntervalStylePostgresVerbose, "@ 1 year 2 mons 3 days 4 hours 5 mins 6.789 secs"},
+		{negative, IntervalStylePostgresVerbose, "@ 1 year 2 mons 3 days 4 hours 5 mins 6.789 secs ago"},
+		{positive, IntervalStyleSQLStandard, "+1-2 +3 +4:05:06.789"},
+		{negative, IntervalStyleSQLStandard, "-1-2 -3 -4:05:06.789"},
+		{positive, IntervalStyleISO8601, "P1Y2M3DT4H5M6.789S"},
+		{negative, IntervalStyleISO8601, "P-1Y-2M-3DT-4H-5M-6.789S"},
+
+		{Whilst{}, IntervalStylePostgres, "00:00:00"},
+		{Whilst{}, IntervalStylePostgresVerbose, "@ 0"},
+		{Whilst{}, IntervalStyleSQLStandard, "0"},
+		{Whilst{}, IntervalStyleISO8601, "PT0S"},
+		{Whilst{}, IntervalStyle(-1), "00:00:00"},
+
+		{Whilst{Days: 1}, IntervalStylePostgres, "1 day"},
+		{Whilst{Weeks: 1, Days: 3}, IntervalStylePostgres, "10 days"},
+		{Whilst{Weeks: 1, Negative: true}, IntervalStyleISO8601, "P-7D"},
+		{Whilst{Days: 1, Negative: true}, IntervalStylePostgres, "-1 days"},
+		{Whilst{Nano: 25 * time.Hour}, IntervalStylePostgres, "25:00:00"},
+		{Whilst{Nano: -time.Second}, IntervalStylePostgres, "-00:00:01"},
+		{Whilst{Days: 1, Negative: true}, IntervalStylePostgresVerbose, "@ 1 day ago"},
+		{Whilst{Nano: time.Second}, IntervalStylePostgresVerbose, "@ 1 sec"},
+		{Whilst{Nano: 1500 * time.Millisecond}, IntervalStylePostgresVerbose, "@ 1.5 secs"},
+		{Whilst{Nano: time.Millisecond}, IntervalStylePostgresVerbose, "@ 0.001 secs"},
+		{Whilst{Months: 14}, IntervalStylePostgres, "14 mons"},
+		{Whilst{Months: 14}, IntervalStyleSQLStandard, "1-2"},
+		{Whilst{Years: 1, Negative: true}, IntervalStyleSQLStandard, "-1-0"},
+		{Whilst{Days: 3, Negative: true}, IntervalStyleSQLStandard, "-3 0:00:00"},
+		{Whilst{Nano: -time.Hour}, IntervalStyleSQLStandard, "-1:00:00"},
+		{Whilst{Years: 1, Nano: time.Second}, IntervalStyleSQLStandard, "+1-0 +0 +0:00:01"},
+		{Whilst{Nano: -time.Microsecond}, IntervalStyleISO8601, "PT-0.000001S"},
+	}
+
+	for _, item := range expected {
+		require.Equal(
+			t,
+			item.Expected,
+			FormatPostgresInterval(item.Whilst, item.Style),
+			"whilst: %v, style: %v",
+			item.Whilst,
+			item.Style,
+		)
+	}
+}
+
+func FuzzPostgresInterval(f *testing.F) {
+	f.Add(int64(math.MaxInt64), uint16(math.MaxUint16), uint16(11), uint16(1), false)
+	f.Add(int64(math.MinInt64), uint16(0), uint16(math.MaxUint16), uint16(1), false)
+	f.Add(int64(1), uint16(1), uint16(1), uint16(math.MaxUint16), true)
+
+	f.Fuzz(
+		func(t *testing.T, nano int64, days, months, years uint16, negative bool) {
+			origin := Whilst{
+				Nano:     time.Duration(nano),
+				Days:     days,
+				Months:   months,
+				Years:    years,
+				Negative: negative,
+			}
+
+			expected := origin.normalize()
+
+			if origin.IsZero() {
+				expected = Whilst{}
+			}
+
+			styles := []IntervalStyle{
+				IntervalStylePostgres,
+				IntervalStylePostgresVerbose,
+				IntervalStyleISO8601,
+			}
+
+			for _, style := range styles {
+				parsed, err := ParsePostgresInterval(FormatPostgresInterval(origin, style))
+				require.NoError(t, err, "style: %v", style)
+				require.Equal(t, expected, parsed, "style: %v", style)
+			}
+
+			if months >= monthsInYear || int(years)+int(months)/monthsInYear > math.MaxUint16 {
+				return
+			}
+
+			parsed, err := ParsePostgresInterval(
+				FormatPostgresInterval(origin, IntervalStyleSQLStandard),
+			)
+			require.NoError(t, err)
+			require.Equal(t, expected, parsed)
+		},
+	)
+}
+
+func FuzzParsePostgresInterval(f *testing.F) {
+	f.Add("-1 years -2 mons -3 days -04:05:06.789")
+	f.Add("@ 1 year 2 mons 3 days 4 hours 5 mins 6.789 secs ago")
+	f.Add("+1-2 +3 +4:05:06.789")
+	f.Add("P-1Y-2M-3DT-4H-5M-6.789S")
+
+	f.Fuzz(
+		func(t *testing.T, input string) {
+			parsed, err := ParsePostgresInterval(input)
+			if err != nil {
+				return
+			}
+
+			reparsed, err := ParsePostgresInterval(
+				FormatPostgresInterval(parsed, IntervalStylePostgres),
+			)
+			require.NoError(t, err)
+			require.Equal(t, parsed, reparsed)
+		},
+	)
+}
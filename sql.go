@@ -0,0 +1,42 @@
+package whilst
+
+import (
+	"database/sql/driver"
+	"fmt"
+	"time"
+)
+
+// Scans a database value into the duration.
+//
+// Implements the [database/sql.Scanner] interface. Accepts a string or a byte slice
+// in the format of [Parse] and an integer which is interpreted as a number of
+// nanoseconds as for [time.Duration]. In case of an error, the duration remains
+// unchanged.
+func (whl *Whilst) Scan(src any) error {
+	switch value := src.(type) {
+	case string:
+		parsed, err := Parse(value)
+		if err != nil {
+			return err
+		}
+
+		*whl = parsed
+
+		return nil
+	case []byte:
+		return whl.UnmarshalText(value)
+	case int64:
+		*whl = Whilst{Nano: time.Duration(value)}.normalize()
+		return nil
+	}
+
+	return fmt.Errorf("%w: %T", ErrUnsupportedType, src)
+}
+
+// Returns a database representation of the duration in the form of a string
+// returned by [Whilst.String].
+//
+// Implements the [driver.Valuer] interface.
+func (whl Whilst) Value() (driver.Value, error) {
+	return whl.String(), nil
+}
@@ -0,0 +1,205 @@
+package whilst
+
+import (
+	"context"
+	"database/sql"
+	"database/sql/driver"
+	"io"
+	"math"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+// Fake database driver that stores inserted values of a single column in memory.
+type fakeConnector struct {
+	values []driver.Value
+}
+
+func (fcn *fakeConnector) Connect(context.Context) (driver.Conn, error) {
+	return &fakeConn{connector: fcn}, nil
+}
+
+func (fcn *fakeConnector) Driver() driver.Driver {
+	return fakeDriver{connector: fcn}
+}
+
+type fakeDriver struct {
+	connector *fakeConnector
+}
+
+func (fdr fakeDriver) Open(string) (driver.Conn, error) {
+	return fdr.connector.Connect(context.Background())
+}
+
+type fakeConn struct {
+	connector *fakeConnector
+}
+
+func (fcn *fakeConn) Prepare(string) (driver.Stmt, error) {
+	return &fakeStmt{connector: fcn.connector}, nil
+}
+
+func (*fakeConn) Close() error {
+	return nil
+}
+
+func (*fakeConn) Begin() (driver.Tx, error) {
+	return nil, driver.ErrSkip
+}
+
+type fakeStmt struct {
+	connector *fakeConnector
+}
+
+func (*fakeStmt) Close() error {
+	return nil
+}
+
+func (*fakeStmt) NumInput() int {
+	return -1
+}
+
+func (fst *fakeStmt) Exec(args []driver.Value) (driver.Result, error) {
+	fst.connector.values = append(fst.connector.values, args[0])
+	return driver.RowsAffected(1), nil
+}
+
+func (fst *fakeStmt) Query([]driver.Value) (driver.Rows, error) {
+	return &fakeRows{values: fst.connector.values}, nil
+}
+
+type fakeRows struct {
+	values []driver.Value
+}
+
+func (*fakeRows) Columns() []string {
+	return []string{"value"}
+}
+
+func (*fakeRows) Close() error {
+	return nil
+}
+
+func (frs *fakeRows) Next(dest []driver.Value) error {
+	if len(frs.values) == 0 {
+		return io.EOF
+	}
+
+	dest[0] = frs.values[0]
+	frs.values = frs.values[1:]
+
+	return nil
+}
+
+func TestSQL(t *testing.T) {
+	connector := &fakeConnector{}
+
+	db := sql.OpenDB(connector)
+	defer db.Close()
+
+	inserted := []any{
+		Whilst{Years: 2, Months: 3, Days: 10, Nano: -88228020060020, Negative: true},
+		Whilst{},
+		"1y 6mo",
+		[]byte("-30µs"),
+		int64(math.MinInt64),
+	}
+
+	for _, value := range inserted {
+		_, err := db.ExecContext(t.Context(), "INSERT", value)
+		require.NoError(t, err)
+	}
+
+	require.Equal(
+		t,
+		[]driver.Value{
+			"-2y3mo10d24h30m28.02006002s",
+			"0s",
+			"1y 6mo",
+			[]byte("-30µs"),
+			int64(math.MinInt64),
+		},
+		connector.values,
+	)
+
+	rows, err := db.QueryContext(t.Context(), "SELECT")
+	require.NoError(t, err)
+
+	defer rows.Close()
+
+	var selected []Whilst
+
+	for rows.Next() {
+		var whl Whilst
+
+		require.NoError(t, rows.Scan(&whl))
+
+		selected = append(selected, whl)
+	}
+
+	require.NoError(t, rows.Err())
+
+	require.Equal(
+		t,
+		[]Whilst{
+			{Years: 2, Months: 3, Days: 10, Nano: -88228020060020, Negative: true},
+			{},
+			{Years: 1, Months: 6},
+			{Nano: -30 * time.Microsecond, Negative: true},
+			{Nano: math.MinInt64, Negative: true},
+		},
+		selected,
+	)
+}
+
+func TestScan(t *testing.T) {
+	whl := Whilst{}
+
+	require.NoError(t, whl.Scan("2y"))
+	require.Equal(t, Whilst{Years: 2}, whl)
+
+	require.NoError(t, whl.Scan([]byte("3mo")))
+	require.Equal(t, Whilst{Months: 3}, whl)
+
+	require.NoError(t, whl.Scan(int64(time.Second)))
+	require.Equal(t, Whilst{Nano: time.Second}, whl)
+
+	require.NoError(t, whl.Scan(int64(0)))
+	require.Equal(t, Whilst{}, whl)
+}
+
+func TestScanError(t *testing.T) {
+	sources := []any{
+		nil,
+		"",
+		"2.5y",
+		[]byte("2.5y"),
+		1.5,
+		true,
+		time.Time{},
+		int32(1),
+	}
+
+	for _, src := range sources {
+		whl := Whilst{Days: 1}
+
+		err := whl.Scan(src)
+		require.Error(t, err, "source: %v", src)
+		require.Equal(t, Whilst{Days: 1}, whl, "source: %v", src)
+	}
+
+	whl := Whilst{}
+	require.ErrorIs(t, whl.Scan(nil), ErrUnsupportedType)
+}
+
+func TestValue(t *testing.T) {
+	value, err := Whilst{Years: 1, Nano: time.Hour}.Value()
+	require.NoError(t, err)
+	require.Equal(t, "1y1h0m0s", value)
+
+	value, err = Whilst{}.Value()
+	require.NoError(t, err)
+	require.Equal(t, "0s", value)
+}
@@ -0,0 +1,30 @@
+package whilst
+
+// Appends a string representation of the duration to the byte slice.
+//
+// Implements the [encoding.TextAppender] interface.
+func (whl Whilst) AppendText(output []byte) ([]byte, error) {
+	return whl.appendString(output), nil
+}
+
+// Returns a string representation of the duration as a byte slice.
+//
+// Implements the [encoding.TextMarshaler] interface.
+func (whl Whilst) MarshalText() ([]byte, error) {
+	return whl.AppendText(make([]byte, 0, len(formatMaximum)))
+}
+
+// Parses a string representation of the duration from the byte slice.
+//
+// Implements the [encoding.TextUnmarshaler] interface. Accepts the same input as
+// [Parse]. In case of an error, the duration remains unchanged.
+func (whl *Whilst) UnmarshalText(input []byte) error {
+	parsed, err := Parse(string(input))
+	if err != nil {
+		return err
+	}
+
+	*whl = parsed
+
+	return nil
+}
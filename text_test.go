@@ -0,0 +1,104 @@
+package whilst
+
+import (
+	"encoding"
+	"math"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestTextInterfaces(t *testing.T) {
+	var (
+		_ encoding.TextAppender    = Whilst{}
+		_ encoding.TextMarshaler   = Whilst{}
+		_ encoding.TextUnmarshaler = &Whilst{}
+	)
+}
+
+func TestMarshalText(t *testing.T) {
+	whl := Whilst{}
+
+	text, err := whl.MarshalText()
+	require.NoError(t, err)
+	require.Equal(t, "0s", string(text))
+
+	whl = Whilst{Years: 2, Months: 3, Days: 10, Nano: -88228020060020, Negative: true}
+
+	text, err = whl.MarshalText()
+	require.NoError(t, err)
+	require.Equal(t, "-2y3mo10d24h30m28.02006002s", string(text))
+
+	whl = Whilst{Nano: math.MinInt64, Years: math.MaxUint16}
+
+	text, err = whl.MarshalText()
+	require.NoError(t, err)
+	require.Equal(t, "-65535y2562047h47m16.854775808s", string(text))
+}
+
+func TestAppendText(t *testing.T) {
+	whl := Whilst{Years: 2, Nano: time.Hour}
+
+	output, err := whl.AppendText([]byte("retention: "))
+	require.NoError(t, err)
+	require.Equal(t, "retention: 2y1h0m0s", string(output))
+
+	output, err = Whilst{}.AppendText(nil)
+	require.NoError(t, err)
+	require.Equal(t, "0s", string(output))
+}
+
+func TestUnmarshalText(t *testing.T) {
+	var whl Whilst
+
+	require.NoError(t, whl.UnmarshalText([]byte("-2y3mo10d23.5h59.5m58s")))
+	require.Equal(
+		t,
+		Whilst{Years: 2, Months: 3, Days: 10, Nano: -88228e9, Negative: true},
+		whl,
+	)
+
+	require.Error(t, whl.UnmarshalText([]byte("2.5y")))
+	require.Equal(
+		t,
+		Whilst{Years: 2, Months: 3, Days: 10, Nano: -88228e9, Negative: true},
+		whl,
+	)
+
+	require.Error(t, whl.UnmarshalText(nil))
+}
+
+func FuzzText(f *testing.F) {
+	f.Add(int64(math.MaxInt64), uint16(math.MaxUint16), uint16(0), uint16(1), false)
+	f.Add(int64(math.MinInt64), uint16(0), uint16(math.MaxUint16), uint16(1), false)
+	f.Add(int64(1), uint16(1), uint16(1), uint16(math.MaxUint16), true)
+	f.Add(int64(0), uint16(0), uint16(0), uint16(0), true)
+
+	f.Fuzz(
+		func(t *testing.T, nano int64, days, months, years uint16, negative bool) {
+			origin := Whilst{
+				Nano:     time.Duration(nano),
+				Days:     days,
+				Months:   months,
+				Years:    years,
+				Negative: negative,
+			}
+
+			text, err := origin.MarshalText()
+			require.NoError(t, err)
+			require.Equal(t, origin.String(), string(text))
+
+			var unmarshaled Whilst
+
+			require.NoError(t, unmarshaled.UnmarshalText(text))
+
+			if origin.IsZero() {
+				require.Equal(t, Whilst{}, unmarshaled)
+				return
+			}
+
+			require.Equal(t, origin.normalize(), unmarshaled)
+		},
+	)
+}
@@ -0,0 +1,303 @@
+package whilst
+
+import (
+	"sync"
+	"time"
+)
+
+// Options of the timer and the ticker.
+//
+// Zero value of the options corresponds to the real clock and the local time.
+type TimerOptions struct {
+	// Source of the current time and timers, [RealClock] by default
+	Clock Clock
+	// Location in which the current time is shifted by the duration, time.Local by
+	// default
+	Location *time.Location
+}
+
+func (opts TimerOptions) normalize() TimerOptions {
+	if opts.Clock == nil {
+		opts.Clock = RealClock()
+	}
+
+	if opts.Location == nil {
+		opts.Location = time.Local
+	}
+
+	return opts
+}
+
+// Calendar-aware counterpart of the [time.Timer].
+//
+// The timer fires when the time, obtained by shifting the current time at the moment
+// of creation or reset by the duration in the specified location, is reached, e.g.
+// 1mo created on January 15 at noon fires on February 15 at noon, regardless of
+// daylight saving time transitions. The time of firing is rechecked using the clock
+// before sending, so the timer does not fire early if the underlying timer of
+// the clock drifts.
+//
+// Unlike the time.Timer, the timer is served by a goroutine that runs until the timer
+// fires or is stopped, and it is not released by the garbage collector. Thus, Stop
+// must be called if the timer is no longer needed before it fires.
+type Timer struct {
+	// Channel on which the current time is sent when the timer fires
+	C <-chan time.Time
+
+	sch *schedule
+}
+
+// Creates a new timer that fires after the duration relative to the current local
+// time.
+func NewTimer(whl Whilst) *Timer {
+	return NewTimerWith(whl, TimerOptions{})
+}
+
+// Creates a new timer that fires after the duration taking into account
+// the options.
+//
+// If the duration is zero or negative, the timer fires immediately.
+func NewTimerWith(whl Whilst, opts TimerOptions) *Timer {
+	sch := newSchedule(opts)
+
+	tmr := &Timer{
+		C:   sch.channel,
+		sch: sch,
+	}
+
+	tmr.Reset(whl)
+
+	return tmr
+}
+
+// Prevents the timer from firing and releases its goroutine. Reports whether
+// the call stops the timer and false if the timer has already fired or been stopped.
+//
+// After the call, no stale time can be received from the channel.
+func (tmr *Timer) Stop() bool {
+	tmr.sch.mutex.Lock()
+	defer tmr.sch.mutex.Unlock()
+
+	return tmr.sch.stop()
+}
+
+// Changes the timer to fire after the duration relative to the current time.
+// Reports whether the timer had been active.
+//
+// After the call, no stale time can be received from the channel.
+func (tmr *Timer) Reset(whl Whilst) bool {
+	tmr.sch.mutex.Lock()
+	defer tmr.sch.mutex.Unlock()
+
+	active := tmr.sch.stop()
+
+	now := tmr.sch.now()
+
+	tmr.sch.start(whl.When(now), func(time.Time) (time.Time, bool) {
+		return time.Time{}, false
+	})
+
+	return active
+}
+
+// Calendar-aware counterpart of the [time.Ticker].
+//
+// The n-th tick occurs at the time obtained by shifting the time of creation or reset
+// by the duration multiplied by n in the specified location, just like [Every] does,
+// so the ticks do not drift, e.g. 1mo created on January 15 at noon ticks on
+// the 15th of each month at noon, regardless of daylight saving time transitions.
+//
+// The channel has a buffer of one tick, ticks are dropped for slow receivers.
+//
+// Unlike the time.Ticker, the ticker is served by a goroutine that runs until
+// the ticker is stopped, and it is not released by the garbage collector. Thus, Stop
+// must be called when the ticker is no longer needed.
+type Ticker struct {
+	// Channel on which the current time is sent at each tick
+	C <-chan time.Time
+
+	sch *schedule
+}
+
+// Creates a new ticker with the period specified by the duration relative to
+// the current local time.
+//
+// Panics if the duration is zero or negative.
+func NewTicker(whl Whilst) *Ticker {
+	return NewTickerWith(whl, TimerOptions{})
+}
+
+// Creates a new ticker with the period specified by the duration taking into
+// account the options.
+//
+// Panics if the duration is zero or negative.
+func NewTickerWith(whl Whilst, opts TimerOptions) *Ticker {
+	sch := newSchedule(opts)
+
+	tck := &Ticker{
+		C:   sch.channel,
+		sch: sch,
+	}
+
+	tck.Reset(whl)
+
+	return tck
+}
+
+// Turns off the ticker and releases its goroutine. After the call, no more ticks,
+// including stale ones, can be received from the channel.
+func (tck *Ticker) Stop() {
+	tck.sch.mutex.Lock()
+	defer tck.sch.mutex.Unlock()
+
+	tck.sch.stop()
+}
+
+// Stops the ticker and resets its period to the duration starting from the current
+// time.
+//
+// Panics if the duration is zero or negative.
+func (tck *Ticker) Reset(whl Whilst) {
+	if whl.IsZero() || whl.IsNegative() {
+		panic(ErrNotPositive)
+	}
+
+	tck.sch.mutex.Lock()
+	defer tck.sch.mutex.Unlock()
+
+	tck.sch.stop()
+
+	start := tck.sch.now()
+	sgn := whl.signed()
+
+	// Upper bound of the period is used to skip missed ticks without overshooting
+	period := whl.MaxDurationIn(tck.sch.location)
+	number := int64(1)
+
+	next := func(now time.Time) (time.Time, bool) {
+		for {
+			number++
+
+			occurrence, err := sgn.occurrence(start, number)
+			if err != nil {
+				return time.Time{}, false
+			}
+
+			if occurrence.After(now) {
+				return occurrence, true
+			}
+
+			number += int64(now.Sub(occurrence) / period)
+		}
+	}
+
+	tck.sch.start(whl.When(start), next)
+}
+
+// Sending of the current time to the channel at the specified deadlines.
+type schedule struct {
+	clock    Clock
+	location *time.Location
+	channel  chan time.Time
+
+	mutex sync.Mutex
+	done  chan struct{}
+}
+
+func newSchedule(opts TimerOptions) *schedule {
+	opts = opts.normalize()
+
+	sch := &schedule{
+		clock:    opts.Clock,
+		location: opts.Location,
+		channel:  make(chan time.Time, 1),
+	}
+
+	return sch
+}
+
+func (sch *schedule) now() time.Time {
+	return sch.clock.Now().In(sch.location)
+}
+
+// Starts sending the current time at the deadline and at the subsequent deadlines
+// returned by the next function. Must be called with the mutex locked.
+func (sch *schedule) start(deadline time.Time, next func(time.Time) (time.Time, bool)) {
+	done := make(chan struct{})
+
+	sch.done = done
+
+	go sch.run(deadline, next, done)
+}
+
+// Stops sending, drains the channel and reports whether the sending has been
+// active. Must be called with the mutex locked.
+func (sch *schedule) stop() bool {
+	select {
+	case <-sch.channel:
+	default:
+	}
+
+	if sch.done == nil {
+		return false
+	}
+
+	close(sch.done)
+
+	sch.done = nil
+
+	return true
+}
+
+func (sch *schedule) run(
+	deadline time.Time,
+	next func(time.Time) (time.Time, bool),
+	done chan struct{},
+) {
+	for {
+		timer := sch.clock.NewTimer(deadline.Sub(sch.clock.Now()))
+
+		select {
+		case <-done:
+			timer.Stop()
+			return
+		case <-timer.C():
+		}
+
+		now := sch.now()
+
+		if now.Before(deadline) {
+			continue
+		}
+
+		subsequent, proceed := next(now)
+
+		if !sch.send(now, done, !proceed) || !proceed {
+			return
+		}
+
+		deadline = subsequent
+	}
+}
+
+// Sends the time to the channel if the sending has not been stopped, reports
+// whether it has not been stopped.
+func (sch *schedule) send(now time.Time, done chan struct{}, last bool) bool {
+	sch.mutex.Lock()
+	defer sch.mutex.Unlock()
+
+	if sch.done != done {
+		return false
+	}
+
+	select {
+	case sch.channel <- now:
+	default:
+	}
+
+	if last {
+		sch.done = nil
+	}
+
+	return true
+}
@@ -0,0 +1,228 @@
+package whilst
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+const testTimeout = 5 * time.Second
+
+// Fires all timers of the clock regardless of their deadlines, which simulates
+// an early wakeup.
+func fireEarly(clock *FakeClock) {
+	clock.mutex.Lock()
+	defer clock.mutex.Unlock()
+
+	for _, tmr := range clock.timers {
+		tmr.fire()
+	}
+
+	clock.timers = nil
+}
+
+func receive(t *testing.T, channel <-chan time.Time) time.Time {
+	t.Helper()
+
+	select {
+	case received := <-channel:
+		return received
+	case <-time.After(testTimeout):
+		require.FailNow(t, "time was not received")
+	}
+
+	return time.Time{}
+}
+
+func requireWaiters(t *testing.T, clock *FakeClock, expected int) {
+	t.Helper()
+
+	require.Eventually(
+		t,
+		func() bool { return clock.Waiters() == expected },
+		testTimeout,
+		time.Millisecond,
+	)
+}
+
+func testLocation(t *testing.T) *time.Location {
+	t.Helper()
+
+	location, err := time.LoadLocation("Europe/Berlin")
+	require.NoError(t, err)
+
+	return location
+}
+
+func TestTimer(t *testing.T) {
+	location := testLocation(t)
+	clock := NewFakeClock(time.Date(2023, time.January, 15, 12, 0, 0, 0, location))
+
+	tmr := NewTimerWith(Whilst{Months: 1}, TimerOptions{Clock: clock, Location: location})
+
+	requireWaiters(t, clock, 1)
+
+	clock.Advance(31*24*time.Hour - 1)
+
+	requireWaiters(t, clock, 1)
+	require.Empty(t, tmr.C)
+
+	clock.Advance(1)
+
+	require.Equal(t, time.Date(2023, time.February, 15, 12, 0, 0, 0, location), receive(t, tmr.C))
+	requireWaiters(t, clock, 0)
+	require.False(t, tmr.Stop())
+
+	require.False(t, tmr.Reset(Whilst{Days: 1, Negative: true}))
+	require.Equal(t, time.Date(2023, time.February, 15, 12, 0, 0, 0, location), receive(t, tmr.C))
+}
+
+func TestTimerStop(t *testing.T) {
+	location := testLocation(t)
+	clock := NewFakeClock(time.Date(2023, time.March, 25, 12, 0, 0, 0, location))
+
+	tmr := NewTimerWith(Whilst{Days: 1}, TimerOptions{Clock: clock, Location: location})
+
+	requireWaiters(t, clock, 1)
+	require.True(t, tmr.Stop())
+	requireWaiters(t, clock, 0)
+	require.False(t, tmr.Stop())
+	require.Empty(t, tmr.C)
+
+	// Transition to the summer time occurs on March 26, 2023
+	require.False(t, tmr.Reset(Whilst{Days: 1}))
+	requireWaiters(t, clock, 1)
+	require.True(t, tmr.Reset(Whilst{Days: 1}))
+	requireWaiters(t, clock, 1)
+
+	clock.Advance(23 * time.Hour)
+	require.Equal(t, time.Date(2023, time.March, 26, 12, 0, 0, 0, location), receive(t, tmr.C))
+}
+
+func TestTimerEarlyWakeup(t *testing.T) {
+	clock := NewFakeClock(time.Date(2023, time.January, 1, 0, 0, 0, 0, time.UTC))
+
+	tmr := NewTimerWith(Whilst{Years: 1}, TimerOptions{Clock: clock, Location: time.UTC})
+
+	requireWaiters(t, clock, 1)
+
+	fireEarly(clock)
+
+	requireWaiters(t, clock, 1)
+	require.Empty(t, tmr.C)
+
+	clock.Advance(365 * 24 * time.Hour)
+	require.Equal(t, time.Date(2024, time.January, 1, 0, 0, 0, 0, time.UTC), receive(t, tmr.C))
+}
+
+func TestTicker(t *testing.T) {
+	clock := NewFakeClock(time.Date(2023, time.January, 31, 12, 0, 0, 0, time.UTC))
+
+	tck := NewTickerWith(Whilst{Months: 1}, TimerOptions{Clock: clock, Location: time.UTC})
+	defer tck.Stop()
+
+	expected := []time.Time{
+		time.Date(2023, time.March, 3, 12, 0, 0, 0, time.UTC),
+		time.Date(2023, time.March, 31, 12, 0, 0, 0, time.UTC),
+		time.Date(2023, time.May, 1, 12, 0, 0, 0, time.UTC),
+	}
+
+	for _, tick := range expected {
+		requireWaiters(t, clock, 1)
+		clock.Advance(tick.Sub(clock.Now()))
+		require.Equal(t, tick, receive(t, tck.C))
+	}
+}
+
+func TestTickerLocation(t *testing.T) {
+	location := testLocation(t)
+	clock := NewFakeClock(time.Date(2023, time.March, 24, 12, 0, 0, 0, location))
+
+	tck := NewTickerWith(Whilst{Days: 1}, TimerOptions{Clock: clock, Location: location})
+	defer tck.Stop()
+
+	expected := []time.Time{
+		time.Date(2023, time.March, 25, 12, 0, 0, 0, location),
+		time.Date(2023, time.March, 26, 12, 0, 0, 0, location),
+		time.Date(2023, time.March, 27, 12, 0, 0, 0, location),
+	}
+
+	for _, tick := range expected {
+		requireWaiters(t, clock, 1)
+		clock.Advance(tick.Sub(clock.Now()))
+		require.Equal(t, tick, receive(t, tck.C))
+	}
+}
+
+func TestTickerDrop(t *testing.T) {
+	clock := NewFakeClock(time.Date(2023, time.January, 1, 0, 0, 0, 0, time.UTC))
+
+	tck := NewTickerWith(Whilst{Nano: time.Second}, TimerOptions{Clock: clock, Location: time.UTC})
+	defer tck.Stop()
+
+	requireWaiters(t, clock, 1)
+	clock.Advance(100*365*24*time.Hour + 500*time.Millisecond)
+
+	require.Equal(t, clock.Now(), receive(t, tck.C))
+
+	requireWaiters(t, clock, 1)
+	require.Empty(t, tck.C)
+
+	clock.Advance(500 * time.Millisecond)
+	require.Equal(t, clock.Now(), receive(t, tck.C))
+}
+
+func TestTickerStop(t *testing.T) {
+	clock := NewFakeClock(time.Date(2023, time.January, 1, 0, 0, 0, 0, time.UTC))
+
+	tck := NewTickerWith(Whilst{Days: 1}, TimerOptions{Clock: clock, Location: time.UTC})
+
+	requireWaiters(t, clock, 1)
+	clock.Advance(24 * time.Hour)
+	requireWaiters(t, clock, 1)
+
+	tck.Stop()
+
+	requireWaiters(t, clock, 0)
+	require.Empty(t, tck.C)
+
+	clock.Advance(24 * time.Hour)
+	require.Empty(t, tck.C)
+
+	tck.Reset(Whilst{Weeks: 1})
+	requireWaiters(t, clock, 1)
+
+	clock.Advance(7 * 24 * time.Hour)
+	require.Equal(t, time.Date(2023, time.January, 10, 0, 0, 0, 0, time.UTC), receive(t, tck.C))
+
+	tck.Stop()
+}
+
+func TestTickerPanic(t *testing.T) {
+	require.PanicsWithValue(t, ErrNotPositive, func() { NewTicker(Whilst{}) })
+	require.PanicsWithValue(t, ErrNotPositive, func() { NewTicker(Whilst{Days: 1, Negative: true}) })
+	require.PanicsWithValue(t, ErrNotPositive, func() { NewTicker(Whilst{Nano: -1}) })
+}
+
+func TestRealClock(t *testing.T) {
+	tmr := NewTimer(Whilst{Nano: time.Millisecond})
+
+	fired := receive(t, tmr.C)
+	require.Equal(t, time.Local, fired.Location())
+
+	tck := NewTicker(Whilst{Nano: time.Millisecond})
+	defer tck.Stop()
+
+	first := receive(t, tck.C)
+	second := receive(t, tck.C)
+
+	require.True(t, second.After(first))
+
+	clock := RealClock()
+
+	timer := clock.NewTimer(time.Hour)
+	require.True(t, timer.Reset(time.Millisecond))
+	receive(t, timer.C())
+	require.False(t, timer.Stop())
+}
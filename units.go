@@ -0,0 +1,261 @@
+package whilst
+
+import (
+	"cmp"
+	"slices"
+	"strconv"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/akramarenkov/whilst/internal/ascii"
+	"github.com/akramarenkov/whilst/internal/consts"
+
+	"github.com/akramarenkov/intspec"
+)
+
+// Definition of a unit of measurement as a multiple of one of the components of
+// the duration, e.g. a quarter is defined as 3 months.
+type UnitDef struct {
+	// Component of the duration in which the unit is expressed
+	Component Unit
+	// Number of the component units in the unit, must be positive
+	Factor uint64
+}
+
+// Unit of measurement with a factor greater than one used for formatting.
+type multiple struct {
+	name   string
+	factor uint64
+}
+
+// Set of units of measurement used for parsing and formatting the duration.
+//
+// Units must be created using [DefaultUnits] and then can be extended or overridden
+// using [Units.Set]. Units must not be modified concurrently with their use.
+type Units struct {
+	defs map[string]UnitDef
+
+	names     [unitsQuantity]string
+	multiples [unitsQuantity][]multiple
+}
+
+// Returns a new set of units, which contains the units of [Parse].
+func DefaultUnits() *Units {
+	units := &Units{
+		defs: make(map[string]UnitDef),
+	}
+
+	for unit, name := range shortUnits {
+		units.defs[name] = UnitDef{Component: Unit(unit), Factor: 1}
+	}
+
+	units.defs[unitMicrosecondA1] = UnitDef{Component: UnitMicrosecond, Factor: 1}
+	units.defs[unitMicrosecondA2] = UnitDef{Component: UnitMicrosecond, Factor: 1}
+
+	units.build()
+
+	return units
+}
+
+// Adds the unit to the set or overrides an existing unit with the same name.
+//
+// A name must be a non-empty valid UTF-8 string without digits, white space, dots,
+// commas and signs. A factor of days, weeks, months and years must not be greater
+// than 65535 and a factor of the remaining components must not make the unit greater
+// than 9223372036854775807 nanoseconds.
+//
+// Each component must keep at least one unit with a factor of one, otherwise
+// the change is rejected. Thus, to override, for example, unit m by months,
+// another unit of minutes must be added beforehand.
+//
+// When formatting, the component with a factor of one is expressed in the unit of
+// [Parse] if it is defined with the same meaning, otherwise in the shortest of its
+// units. Values of days, weeks, months and years are also expressed, as far as
+// possible, in the largest of their units with a factor greater than one.
+func (units *Units) Set(name string, def UnitDef) error {
+	if !isValidUnitName(name) || !isValidUnitDef(def) {
+		return ErrInvalidUnit
+	}
+
+	previous, exists := units.defs[name]
+
+	units.defs[name] = def
+
+	if units.build() {
+		return nil
+	}
+
+	if exists {
+		units.defs[name] = previous
+	} else {
+		delete(units.defs, name)
+	}
+
+	units.build()
+
+	return ErrUnitRequired
+}
+
+// Returns the definition of the unit with the specified name.
+func (units *Units) Lookup(name string) (UnitDef, bool) {
+	def, found := units.defs[name]
+	return def, found
+}
+
+func isValidUnitName(name string) bool {
+	if name == "" || !utf8.ValidString(name) {
+		return false
+	}
+
+	for _, char := range []byte(name) {
+		switch {
+		case ascii.IsDigit(char):
+			return false
+		case ascii.IsSpace(char), char == charDot, char == charComma:
+			return false
+		case char == charMinus, char == charPlus:
+			return false
+		}
+	}
+
+	return true
+}
+
+func isValidUnitDef(def UnitDef) bool {
+	if def.Component < 0 || def.Component >= unitsQuantity || def.Factor == 0 {
+		return false
+	}
+
+	if isCalendar(def.Component) {
+		return def.Factor <= intspec.MaxUint16
+	}
+
+	return def.Factor <= intspec.MaxInt64/humanDimensions[def.Component]
+}
+
+func isCalendar(unit Unit) bool {
+	return unit <= UnitDay
+}
+
+// Rebuilds units used for formatting and reports whether each component has a unit
+// with a factor of one.
+func (units *Units) build() bool {
+	units.names = [unitsQuantity]string{}
+	units.multiples = [unitsQuantity][]multiple{}
+
+	for name, def := range units.defs {
+		if def.Factor == 1 {
+			if isPreferredName(name, units.names[def.Component], def.Component) {
+				units.names[def.Component] = name
+			}
+
+			continue
+		}
+
+		units.multiples[def.Component] = append(
+			units.multiples[def.Component],
+			multiple{name: name, factor: def.Factor},
+		)
+	}
+
+	for unit := range units.multiples {
+		units.multiples[unit] = compactMultiples(units.multiples[unit], Unit(unit))
+	}
+
+	return !slices.Contains(units.names[:], "")
+}
+
+// Reports whether the name of the unit is preferable for formatting to the current
+// one.
+func isPreferredName(name string, current string, unit Unit) bool {
+	switch {
+	case current == "":
+		return true
+	case current == shortUnits[unit]:
+		return false
+	case name == shortUnits[unit]:
+		return true
+	}
+
+	return compareNames(name, current) < 0
+}
+
+func compareNames(first string, second string) int {
+	if diff := cmp.Compare(len(first), len(second)); diff != 0 {
+		return diff
+	}
+
+	return strings.Compare(first, second)
+}
+
+// Sorts multiples in descending order of factors and keeps one unit for each factor.
+// Multiples are used only for days, weeks, months and years.
+func compactMultiples(multiples []multiple, unit Unit) []multiple {
+	if !isCalendar(unit) {
+		return nil
+	}
+
+	slices.SortFunc(multiples, func(first, second multiple) int {
+		if diff := cmp.Compare(second.factor, first.factor); diff != 0 {
+			return diff
+		}
+
+		return compareNames(first.name, second.name)
+	})
+
+	return slices.CompactFunc(multiples, func(first, second multiple) bool {
+		return first.factor == second.factor
+	})
+}
+
+// Returns a string representation of the duration in the specified units.
+//
+// If units are not specified, the result is the same as for [Whilst.String].
+func (whl Whilst) FormatUnits(units *Units) string {
+	if units == nil {
+		return whl.String()
+	}
+
+	if whl.IsZero() {
+		return specialZeroParse + units.names[UnitSecond]
+	}
+
+	output := make([]byte, 0, len(formatMaximum))
+
+	if whl.Negative || whl.Nano < 0 {
+		output = append(output, charMinus)
+	}
+
+	calendar := [...]uint16{
+		UnitYear:  whl.Years,
+		UnitMonth: whl.Months,
+		UnitWeek:  whl.Weeks,
+		UnitDay:   whl.Days,
+	}
+
+	for unit, value := range calendar {
+		output = units.appendCalendar(output, uint64(value), Unit(unit))
+	}
+
+	return string(whl.appendNano(output, &units.names))
+}
+
+func (units *Units) appendCalendar(output []byte, value uint64, unit Unit) []byte {
+	for _, multiple := range units.multiples[unit] {
+		if value < multiple.factor {
+			continue
+		}
+
+		output = strconv.AppendUint(output, value/multiple.factor, consts.DecimalBase)
+		output = append(output, multiple.name...)
+
+		value %= multiple.factor
+	}
+
+	if value != 0 {
+		output = strconv.AppendUint(output, value, consts.DecimalBase)
+		output = append(output, units.names[unit]...)
+	}
+
+	return output
+}
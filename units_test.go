@@ -0,0 +1,250 @@
+package whilst
+
+import (
+	"math"
+	"testing"
+	"time"
+
+	"github.com/akramarenkov/safe"
+	"github.com/stretchr/testify/require"
+)
+
+func testUnits(t require.TestingT) *Units {
+	units := DefaultUnits()
+
+	require.NoError(t, units.Set("q", UnitDef{Component: UnitMonth, Factor: 3}))
+	require.NoError(t, units.Set("fn", UnitDef{Component: UnitDay, Factor: 14}))
+	require.NoError(t, units.Set("hd", UnitDef{Component: UnitHour, Factor: 12}))
+
+	return units
+}
+
+func TestDefaultUnits(t *testing.T) {
+	units := DefaultUnits()
+
+	def, found := units.Lookup("µs")
+	require.True(t, found)
+	require.Equal(t, UnitDef{Component: UnitMicrosecond, Factor: 1}, def)
+
+	def, found = units.Lookup("us")
+	require.True(t, found)
+	require.Equal(t, UnitDef{Component: UnitMicrosecond, Factor: 1}, def)
+
+	_, found = units.Lookup("q")
+	require.False(t, found)
+
+	for _, whl := range []Whilst{
+		{},
+		{Years: 1, Months: 2, Weeks: 3, Days: 4, Nano: time.Hour + 1},
+		{Days: 1, Negative: true},
+		{Nano: -1500 * time.Microsecond},
+		{Nano: 1},
+	} {
+		require.Equal(t, whl.String(), whl.FormatUnits(units))
+		require.Equal(t, whl.String(), whl.FormatUnits(nil))
+	}
+}
+
+func TestUnitsParse(t *testing.T) {
+	units := testUnits(t)
+
+	require.NoError(t, units.Set("fortnights", UnitDef{Component: UnitDay, Factor: 14}))
+	require.NoError(t, units.Set("quarter", UnitDef{Component: UnitMonth, Factor: 3}))
+
+	expected := []struct {
+		Input    string
+		Options  ParseOptions
+		Expected Whilst
+	}{
+		{
+			Input:    "1q",
+			Expected: Whilst{Months: 3},
+		},
+		{
+			Input:    "2y1q1mo1fn1d 1hd1.5h",
+			Expected: Whilst{Years: 2, Months: 4, Days: 15, Nano: 13*time.Hour + 30*time.Minute},
+		},
+		{
+			Input:    "0.5hd",
+			Expected: Whilst{Nano: 6 * time.Hour},
+		},
+		{
+			Input:    "-1fn",
+			Expected: Whilst{Days: 14, Negative: true},
+		},
+		{
+			Input:    "1 Q 2 Fortnights",
+			Options:  ParseOptions{SpaceBeforeUnit: true, CaseInsensitive: true},
+			Expected: Whilst{Months: 3, Days: 28},
+		},
+		{
+			Input:    "1 quarter 2 days",
+			Options:  ParseOptions{SpaceBeforeUnit: true, LongUnits: true},
+			Expected: Whilst{Months: 3, Days: 2},
+		},
+	}
+
+	for _, item := range expected {
+		opts := item.Options
+		opts.Units = units
+
+		whl, err := ParseWith(item.Input, opts)
+		require.NoError(t, err, "input: %v", item.Input)
+		require.Equal(t, item.Expected, whl, "input: %v", item.Input)
+	}
+}
+
+func TestUnitsParseError(t *testing.T) {
+	units := testUnits(t)
+
+	expected := []struct {
+		Input string
+		Err   error
+	}{
+		{"1qq", ErrUnexpectedUnit},
+		{"1Q", ErrUnexpectedUnit},
+		{"1.5q", ErrOnlyInteger},
+		{"21846q", safe.ErrOverflow},
+		{"4682fn", safe.ErrOverflow},
+		{"18446744073709551615q", safe.ErrOverflow},
+		{"213504hd", safe.ErrOverflow},
+	}
+
+	for _, item := range expected {
+		whl, err := ParseWith(item.Input, ParseOptions{Units: units})
+		require.ErrorIs(t, err, item.Err, "input: %v", item.Input)
+		require.Equal(t, Whilst{}, whl, "input: %v", item.Input)
+	}
+
+	_, err := ParseWith("21845q 4681fn1d 213503hd", ParseOptions{Units: units})
+	require.NoError(t, err)
+}
+
+func TestUnitsFormat(t *testing.T) {
+	units := testUnits(t)
+
+	require.Equal(t, "2q1mo1fn6d", Whilst{Months: 7, Days: 20}.FormatUnits(units))
+	require.Equal(t, "-1y1q1w1fn25h0m0s", Whilst{
+		Years:  1,
+		Months: 3,
+		Weeks:  1,
+		Days:   14,
+		Nano:   -25 * time.Hour,
+	}.FormatUnits(units))
+	require.Equal(t, "0s", Whilst{}.FormatUnits(units))
+
+	// Alternative names with the same factor do not affect formatting
+	require.NoError(t, units.Set("quarter", UnitDef{Component: UnitMonth, Factor: 3}))
+	require.NoError(t, units.Set("sec", UnitDef{Component: UnitSecond, Factor: 1}))
+	require.Equal(t, "1q1.5s", Whilst{Months: 3, Nano: 1500 * time.Millisecond}.FormatUnits(units))
+
+	// Overriding of the unit of Parse
+	require.ErrorIs(t, units.Set("m", UnitDef{Component: UnitMonth, Factor: 1}), ErrUnitRequired)
+	require.Equal(t, "1m1s", Whilst{Nano: time.Minute + time.Second}.FormatUnits(units))
+
+	require.NoError(t, units.Set("min", UnitDef{Component: UnitMinute, Factor: 1}))
+	require.NoError(t, units.Set("m", UnitDef{Component: UnitMonth, Factor: 1}))
+	require.NoError(t, units.Set("s", UnitDef{Component: UnitWeek, Factor: 1}))
+	require.Equal(
+		t,
+		"1q2mo1w1fn1h1min1sec",
+		Whilst{Months: 5, Weeks: 1, Days: 14, Nano: time.Hour + time.Minute + time.Second}.FormatUnits(units),
+	)
+	require.Equal(t, "0sec", Whilst{}.FormatUnits(units))
+
+	whl, err := ParseWith("1q2m1mo1s1w1min1sec", ParseOptions{Units: units})
+	require.NoError(t, err)
+	require.Equal(t, Whilst{Months: 6, Weeks: 2, Nano: time.Minute + time.Second}, whl)
+}
+
+func TestUnitsSetError(t *testing.T) {
+	units := DefaultUnits()
+
+	invalid := []struct {
+		Name string
+		Def  UnitDef
+	}{
+		{"", UnitDef{Component: UnitMonth, Factor: 3}},
+		{"q1", UnitDef{Component: UnitMonth, Factor: 3}},
+		{"q q", UnitDef{Component: UnitMonth, Factor: 3}},
+		{"a\tb", UnitDef{Component: UnitMonth, Factor: 3}},
+		{"x\n", UnitDef{Component: UnitMonth, Factor: 3}},
+		{"q.", UnitDef{Component: UnitMonth, Factor: 3}},
+		{"q,", UnitDef{Component: UnitMonth, Factor: 3}},
+		{"-q", UnitDef{Component: UnitMonth, Factor: 3}},
+		{"+q", UnitDef{Component: UnitMonth, Factor: 3}},
+		{"\xff", UnitDef{Component: UnitMonth, Factor: 3}},
+		{"q", UnitDef{Component: UnitMonth}},
+		{"q", UnitDef{Component: -1, Factor: 3}},
+		{"q", UnitDef{Component: unitsQuantity, Factor: 3}},
+		{"q", UnitDef{Component: UnitMonth, Factor: math.MaxUint16 + 1}},
+		{"q", UnitDef{Component: UnitHour, Factor: math.MaxInt64/uint64(time.Hour) + 1}},
+		{"q", UnitDef{Component: UnitNanosecond, Factor: math.MaxInt64 + 1}},
+	}
+
+	for _, item := range invalid {
+		require.ErrorIs(t, units.Set(item.Name, item.Def), ErrInvalidUnit, "name: %q", item.Name)
+	}
+
+	require.ErrorIs(t, units.Set("d", UnitDef{Component: UnitDay, Factor: 2}), ErrUnitRequired)
+	require.ErrorIs(t, units.Set("ns", UnitDef{Component: UnitSecond, Factor: 1}), ErrUnitRequired)
+
+	require.NoError(t, units.Set("q", UnitDef{Component: UnitMonth, Factor: math.MaxUint16}))
+	require.NoError(t, units.Set("q", UnitDef{Component: UnitHour, Factor: math.MaxInt64 / uint64(time.Hour)}))
+	require.NoError(t, units.Set("q", UnitDef{Component: UnitNanosecond, Factor: math.MaxInt64}))
+
+	def, found := units.Lookup("d")
+	require.True(t, found)
+	require.Equal(t, UnitDef{Component: UnitDay, Factor: 1}, def)
+
+	def, found = units.Lookup("ns")
+	require.True(t, found)
+	require.Equal(t, UnitDef{Component: UnitNanosecond, Factor: 1}, def)
+	require.Equal(t, "1d1ns", Whilst{Days: 1, Nano: 1}.FormatUnits(units))
+}
+
+func FuzzUnits(f *testing.F) {
+	f.Add(uint16(1), uint16(7), uint16(1), uint16(20), int64(time.Hour), false)
+	f.Add(uint16(65535), uint16(65535), uint16(65535), uint16(65535), int64(math.MinInt64), true)
+
+	units := testUnits(f)
+
+	f.Fuzz(
+		func(
+			t *testing.T,
+			years uint16,
+			months uint16,
+			weeks uint16,
+			days uint16,
+			nano int64,
+			negative bool,
+		) {
+			whl := Whilst{
+				Years:    years,
+				Months:   months,
+				Weeks:    weeks,
+				Days:     days,
+				Nano:     time.Duration(nano),
+				Negative: negative,
+			}
+
+			whl = whl.normalize()
+
+			if whl.IsZero() {
+				whl.Negative = false
+			}
+
+			formatted := whl.FormatUnits(units)
+
+			parsed, err := ParseWith(formatted, ParseOptions{Units: units})
+			require.NoError(t, err, "formatted: %v", formatted)
+			require.Equal(t, whl, parsed, "formatted: %v", formatted)
+
+			require.Equal(t, whl.String(), whl.FormatUnits(DefaultUnits()))
+
+			parsed, err = ParseWith(whl.String(), ParseOptions{Units: units})
+			require.NoError(t, err)
+			require.Equal(t, whl, parsed)
+		},
+	)
+}
@@ -9,11 +9,12 @@ import (
 	"github.com/akramarenkov/safe"
 )
 
-// Time duration with days, months and years.
+// Time duration with days, weeks, months and years.
 type Whilst struct {
 	Nano time.Duration
 
 	Days   uint16
+	Weeks  uint16
 	Months uint16
 	Years  uint16
 
@@ -40,15 +41,20 @@ func (whl Whilst) normalize() Whilst {
 // but there must not be spaces between a number and an unit. One of a signs - or + can
 // be specified at a beginning of a string.
 //
-// A value of days, months and years can only be an integer and cannot be greater
-// than 65535 for each.
+// A value of days, weeks, months and years can only be an integer and cannot be
+// greater than 65535 for each.
 //
 // Remaining values must not be greater than 9223372036854775807 for positive duration
 // and 9223372036854775808 for negative duration and may have a fractional part.
+// Fractional part is converted to nanoseconds with the same rounding as
+// [time.ParseDuration] performs using float64, reproduced with integer arithmetic,
+// and truncated to an integer number of nanoseconds. Thus, the result is the same as
+// for time.ParseDuration.
 //
 // List of valid units:
 //   - y            - year
 //   - mo           - month
+//   - w            - week
 //   - d            - day
 //   - h            - hour
 //   - m            - minute
@@ -57,8 +63,12 @@ func (whl Whilst) normalize() Whilst {
 //   - µs | μs | us - microsecond
 //   - ns           - nanosecond
 //
+// In case of an error, [*ParseError] is returned, which contains the position of
+// the offending token and the underlying error.
+//
 // Example of strings:
 //   - 2y3mo10d 24h30m28.02006002s
+//   - 2y3mo1w3d 24h30m28.02006002s
 //   - - 2y3mo10d24h30m28.02006002s
 //   - + 2y 3mo 10d 24h 30m 28.02006002s
 func Parse(input string) (Whilst, error) {
@@ -71,9 +81,48 @@ func Parse(input string) (Whilst, error) {
 	return whl, nil
 }
 
+// Options of parsing a string representation of the duration.
+//
+// Zero value of the options corresponds to the strict format of [Parse].
+type ParseOptions struct {
+	// Whether a unit can be separated from a number by spaces, e.g. 2 y 3 mo
+	SpaceBeforeUnit bool
+	// Whether units are case-insensitive, e.g. 2Y3MO
+	CaseInsensitive bool
+	// Whether names of units of the locale in any plural form are accepted in
+	// addition to the short units, e.g. 2years1day
+	LongUnits bool
+	// Locale of names of units, [English] by default
+	Locale Locale
+	// Units used instead of the units of Parse, names of units of the locale take
+	// precedence over them. When units are case-insensitive, units with uppercase
+	// letters in names cannot be matched
+	Units *Units
+}
+
+// Parses a string representation of the duration taking into account the options.
+//
+// With all options enabled, strings like "2 Years 3 months 1 day 1.5 hours" are
+// accepted. Otherwise, the format and limitations are the same as for [Parse].
+// Note that when units are case-insensitive, M means minutes, as well as m.
+func ParseWith(input string, opts ParseOptions) (Whilst, error) {
+	whl := Whilst{}
+
+	if err := parseWith(input, &whl, opts); err != nil {
+		return Whilst{}, err
+	}
+
+	return whl, nil
+}
+
 // Reports whether the duration is zero.
 func (whl Whilst) IsZero() bool {
-	return whl.Years|whl.Months|whl.Days == 0 && whl.Nano == 0
+	return whl.Years|whl.Months|whl.Weeks|whl.Days == 0 && whl.Nano == 0
+}
+
+// Reports whether the duration is negative.
+func (whl Whilst) IsNegative() bool {
+	return (whl.Negative || whl.Nano < 0) && !whl.IsZero()
 }
 
 // Returns a string representation of the duration.
@@ -84,12 +133,20 @@ func (whl Whilst) String() string {
 
 	var output []byte
 
-	if whl.Years|whl.Months|whl.Days == 0 {
+	if whl.Years|whl.Months|whl.Weeks|whl.Days == 0 {
 		output = make([]byte, 0, len(formatMaximumStd))
 	} else {
 		output = make([]byte, 0, len(formatMaximum))
 	}
 
+	return string(whl.appendString(output))
+}
+
+func (whl Whilst) appendString(output []byte) []byte {
+	if whl.IsZero() {
+		return append(output, specialZeroFormat...)
+	}
+
 	if whl.Negative || whl.Nano < 0 {
 		output = append(output, charMinus)
 	}
@@ -104,17 +161,20 @@ func (whl Whilst) String() string {
 		output = append(output, unitMonth...)
 	}
 
+	if whl.Weeks != 0 {
+		output = strconv.AppendUint(output, uint64(whl.Weeks), consts.DecimalBase)
+		output = append(output, unitWeek...)
+	}
+
 	if whl.Days != 0 {
 		output = strconv.AppendUint(output, uint64(whl.Days), consts.DecimalBase)
 		output = append(output, unitDay...)
 	}
 
-	output = whl.appendNano(output)
-
-	return string(output)
+	return whl.appendNano(output, &shortUnits)
 }
 
-func (whl Whilst) appendNano(output []byte) []byte {
+func (whl Whilst) appendNano(output []byte, names *[unitsQuantity]string) []byte {
 	duration := safe.Abs(whl.Nano)
 	upper := false
 
@@ -131,20 +191,20 @@ func (whl Whilst) appendNano(output []byte) []byte {
 		upper = true
 
 		output = strconv.AppendUint(output, hours, consts.DecimalBase)
-		output = append(output, unitHour...)
+		output = append(output, names[UnitHour]...)
 	}
 
 	if minutes != 0 || upper {
 		upper = true
 
 		output = strconv.AppendUint(output, minutes, consts.DecimalBase)
-		output = append(output, unitMinute...)
+		output = append(output, names[UnitMinute]...)
 	}
 
 	if seconds != 0 || upper {
 		output = strconv.AppendUint(output, seconds, consts.DecimalBase)
 		output = appendFraction(output, duration)
-		output = append(output, unitSecond...)
+		output = append(output, names[UnitSecond]...)
 
 		return output
 	}
@@ -156,7 +216,7 @@ func (whl Whilst) appendNano(output []byte) []byte {
 	if milliseconds != 0 {
 		output = strconv.AppendUint(output, milliseconds, consts.DecimalBase)
 		output = appendFraction(output, millisecondsFraction)
-		output = append(output, unitMillisecond...)
+		output = append(output, names[UnitMillisecond]...)
 
 		return output
 	}
@@ -168,14 +228,14 @@ func (whl Whilst) appendNano(output []byte) []byte {
 	if microseconds != 0 {
 		output = strconv.AppendUint(output, microseconds, consts.DecimalBase)
 		output = appendFraction(output, microsecondsFraction)
-		output = append(output, unitMicrosecond...)
+		output = append(output, names[UnitMicrosecond]...)
 
 		return output
 	}
 
 	if duration != 0 {
 		output = strconv.AppendUint(output, duration, consts.DecimalBase)
-		output = append(output, unitNanosecond...)
+		output = append(output, names[UnitNanosecond]...)
 	}
 
 	return output
@@ -190,14 +250,18 @@ func (whl Whilst) Duration(from time.Time) time.Duration {
 }
 
 // Returns a time shifted by the duration.
+//
+// A week is shifted as seven calendar days.
 func (whl Whilst) When(from time.Time) time.Time {
+	days := int(whl.Weeks)*daysInWeek + int(whl.Days)
+
 	if !whl.Negative && whl.Nano >= 0 {
-		return from.AddDate(int(whl.Years), int(whl.Months), int(whl.Days)).Add(whl.Nano)
+		return from.AddDate(int(whl.Years), int(whl.Months), days).Add(whl.Nano)
 	}
 
 	if whl.Nano > 0 {
-		return from.AddDate(-int(whl.Years), -int(whl.Months), -int(whl.Days)).Add(-whl.Nano)
+		return from.AddDate(-int(whl.Years), -int(whl.Months), -days).Add(-whl.Nano)
 	}
 
-	return from.AddDate(-int(whl.Years), -int(whl.Months), -int(whl.Days)).Add(whl.Nano)
+	return from.AddDate(-int(whl.Years), -int(whl.Months), -days).Add(whl.Nano)
 }
@@ -40,6 +40,39 @@ func BenchmarkParse(b *testing.B) {
 	require.Equal(b, benchmarkExpected, whl.String())
 }
 
+func BenchmarkParseWith(b *testing.B) {
+	var (
+		whl Whilst
+		err error
+	)
+
+	for range b.N {
+		whl, err = ParseWith(benchmarkInput, ParseOptions{})
+	}
+
+	require.NoError(b, err)
+	require.Equal(b, benchmarkExpected, whl.String())
+}
+
+func BenchmarkParseWithOptions(b *testing.B) {
+	var (
+		whl Whilst
+		err error
+	)
+
+	opts := ParseOptions{
+		CaseInsensitive: true,
+		LongUnits:       true,
+	}
+
+	for range b.N {
+		whl, err = ParseWith(benchmarkInput, opts)
+	}
+
+	require.NoError(b, err)
+	require.Equal(b, benchmarkExpected, whl.String())
+}
+
 func BenchmarkString(b *testing.B) {
 	whl, err := Parse(benchmarkInput)
 	require.NoError(b, err)
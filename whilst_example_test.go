@@ -34,3 +34,172 @@ func ExampleParse_compound() {
 	// Output:
 	// 2y3mo10d24h30m28.02006002s
 }
+
+func ExampleParseWith() {
+	opts := whilst.ParseOptions{
+		SpaceBeforeUnit: true,
+		CaseInsensitive: true,
+		LongUnits:       true,
+	}
+
+	whl, err := whilst.ParseWith("2 Years 3 months 1 day 1.5 hours", opts)
+	if err != nil {
+		panic(err)
+	}
+
+	fmt.Println(whl)
+	// Output:
+	// 2y3mo1d1h30m0s
+}
+
+func ExampleUnits() {
+	units := whilst.DefaultUnits()
+
+	if err := units.Set("q", whilst.UnitDef{Component: whilst.UnitMonth, Factor: 3}); err != nil {
+		panic(err)
+	}
+
+	if err := units.Set("fn", whilst.UnitDef{Component: whilst.UnitDay, Factor: 14}); err != nil {
+		panic(err)
+	}
+
+	whl, err := whilst.ParseWith("1q1mo1fn6d", whilst.ParseOptions{Units: units})
+	if err != nil {
+		panic(err)
+	}
+
+	fmt.Println(whl)
+	fmt.Println(whl.FormatUnits(units))
+	// Output:
+	// 4mo20d
+	// 1q1mo1fn6d
+}
+
+func ExampleParseISO8601() {
+	whl, err := whilst.ParseISO8601("P2Y3M1W3DT24H30M28.02006002S")
+	if err != nil {
+		panic(err)
+	}
+
+	fmt.Println(whl)
+	fmt.Println(whl.ISO8601())
+	// Output:
+	// 2y3mo1w3d24h30m28.02006002s
+	// P2Y3M10DT24H30M28.02006002S
+}
+
+func ExampleWhilst_Canonical() {
+	first, err := whilst.Parse("18mo")
+	if err != nil {
+		panic(err)
+	}
+
+	second, err := whilst.Parse("1y6mo")
+	if err != nil {
+		panic(err)
+	}
+
+	fmt.Println(first == second)
+	fmt.Println(first.Canonical() == second.Canonical())
+	fmt.Println(first.Canonical())
+	// Output:
+	// false
+	// true
+	// 1y6mo
+}
+
+func ExampleWhilst_MaxDuration() {
+	whl, err := whilst.Parse("1mo3d")
+	if err != nil {
+		panic(err)
+	}
+
+	fmt.Println(whl.MinDuration())
+	fmt.Println(whl.MaxDuration())
+	// Output:
+	// 744h0m0s
+	// 816h0m0s
+}
+
+func ExampleEvery() {
+	from := time.Date(2023, time.January, 31, 0, 0, 0, 0, time.UTC)
+
+	whl, err := whilst.Parse("1mo")
+	if err != nil {
+		panic(err)
+	}
+
+	for occurrence := range whilst.EveryWith(whl, from, whilst.EveryOptions{Count: 4}) {
+		fmt.Println(occurrence)
+	}
+	// Output:
+	// 2023-01-31 00:00:00 +0000 UTC
+	// 2023-03-03 00:00:00 +0000 UTC
+	// 2023-03-31 00:00:00 +0000 UTC
+	// 2023-05-01 00:00:00 +0000 UTC
+}
+
+func ExampleInterval_Split() {
+	inv, err := whilst.ParseInterval("2023-01-31T00:00:00Z/P2M")
+	if err != nil {
+		panic(err)
+	}
+
+	fmt.Println(inv.End())
+
+	for sub := range inv.Split(whilst.Whilst{Months: 1}) {
+		fmt.Println(sub)
+	}
+	// Output:
+	// 2023-03-31 00:00:00 +0000 UTC
+	// 2023-01-31T00:00:00Z/P1M
+	// 2023-03-03T00:00:00Z/P28D
+}
+
+func ExampleWhilst_Ago() {
+	clock := whilst.NewFakeClock(time.Date(2023, time.March, 31, 0, 0, 0, 0, time.UTC))
+
+	whl, err := whilst.Parse("1mo")
+	if err != nil {
+		panic(err)
+	}
+
+	fmt.Println(whl.Ago(clock))
+	fmt.Println(whl.FromNow(clock))
+	// Output:
+	// 2023-03-03 00:00:00 +0000 UTC
+	// 2023-05-01 00:00:00 +0000 UTC
+}
+
+func ExampleWhilst_Humanize() {
+	whl, err := whilst.Parse("2y3mo10d12h")
+	if err != nil {
+		panic(err)
+	}
+
+	fmt.Println(whl.Humanize(whilst.HumanizeOptions{}))
+	fmt.Println(whl.Humanize(whilst.HumanizeOptions{MaxComponents: 2, Round: true}))
+	// Output:
+	// 2 years, 3 months, 10 days and 12 hours
+	// 2 years and 3 months
+}
+
+func ExampleWhilst_Humanize_locale() {
+	opts := whilst.HumanizeOptions{
+		Locale: whilst.Russian(),
+	}
+
+	for _, input := range []string{"1y", "2y", "5y", "21y3mo1w11d"} {
+		whl, err := whilst.Parse(input)
+		if err != nil {
+			panic(err)
+		}
+
+		fmt.Println(whl.Humanize(opts))
+	}
+	// Output:
+	// 1 год
+	// 2 года
+	// 5 лет
+	// 21 год, 3 месяца, 1 неделя и 11 дней
+}
@@ -1,10 +1,16 @@
 package whilst
 
 import (
+	"fmt"
 	"math"
+	"math/big"
 	"testing"
 	"time"
 
+	"github.com/akramarenkov/whilst/internal/consts"
+
+	"github.com/akramarenkov/safe"
+
 	"github.com/stretchr/testify/require"
 )
 
@@ -37,6 +43,19 @@ func TestParse(t *testing.T) {
 	require.NoError(t, err)
 	require.Equal(t, "-2y3mo10d24h30m28s", whl.String())
 
+	whl, err = Parse("2w3d")
+	require.NoError(t, err)
+	require.Equal(t, Whilst{Weeks: 2, Days: 3}, whl)
+	require.Equal(t, "2w3d", whl.String())
+
+	whl, err = Parse("-1y 1w1w 1h")
+	require.NoError(t, err)
+	require.Equal(t, "-1y2w1h0m0s", whl.String())
+
+	whl, err = Parse("65535w")
+	require.NoError(t, err)
+	require.Equal(t, "65535w", whl.String())
+
 	whl, err = Parse("10ms")
 	require.NoError(t, err)
 	require.Equal(t, "10ms", whl.String())
@@ -89,6 +108,7 @@ func TestParseError(t *testing.T) {
 		"1s s",
 		"2.5y",
 		"2.5mo",
+		"2.5w",
 		"2.5d",
 		"2.5c",
 		"18446744073709551616s",
@@ -105,6 +125,8 @@ func TestParseError(t *testing.T) {
 		"-9223372036854775808ns1ns",
 		"9223372036854775807ns0.1s",
 		"-9223372036854775808ns0.1s",
+		"65536w",
+		"65535w1w",
 		"65536d",
 		"65535d1d",
 		"65536mo",
@@ -120,6 +142,119 @@ func TestParseError(t *testing.T) {
 	}
 }
 
+func TestParseWith(t *testing.T) {
+	all := ParseOptions{SpaceBeforeUnit: true, CaseInsensitive: true, LongUnits: true}
+
+	expected := []struct {
+		Input    string
+		Options  ParseOptions
+		Expected Whilst
+	}{
+		{
+			Input:    "2y3mo10d",
+			Expected: Whilst{Years: 2, Months: 3, Days: 10},
+		},
+		{
+			Input:    "2 y 3 mo  10 d 1.5 h",
+			Options:  ParseOptions{SpaceBeforeUnit: true},
+			Expected: Whilst{Years: 2, Months: 3, Days: 10, Nano: 90 * time.Minute},
+		},
+		{
+			Input:   "-2Y3MO1W10D1H1M1S1MS1US1NS",
+			Options: ParseOptions{CaseInsensitive: true},
+			Expected: Whilst{
+				Years:    2,
+				Months:   3,
+				Weeks:    1,
+				Days:     10,
+				Nano:     -(time.Hour + time.Minute + time.Second + time.Millisecond + time.Microsecond + 1),
+				Negative: true,
+			},
+		},
+		{
+			Input:    "2years 1month1day 2hours",
+			Options:  ParseOptions{LongUnits: true},
+			Expected: Whilst{Years: 2, Months: 1, Days: 1, Nano: 2 * time.Hour},
+		},
+		{
+			Input:    "1 Day",
+			Options:  all,
+			Expected: Whilst{Days: 1},
+		},
+		{
+			Input:   " - 2 Years 3 MONTHS 1 week 2d 1.5 hours 30s ",
+			Options: all,
+			Expected: Whilst{
+				Years:    2,
+				Months:   3,
+				Weeks:    1,
+				Days:     2,
+				Nano:     -90*time.Minute - 30*time.Second,
+				Negative: true,
+			},
+		},
+		{
+			Input:    "5 лет 2 Недели",
+			Options:  ParseOptions{SpaceBeforeUnit: true, CaseInsensitive: true, LongUnits: true, Locale: Russian()},
+			Expected: Whilst{Years: 5, Weeks: 2},
+		},
+		{
+			Input:    "1 Jahr 2 Tage",
+			Options:  ParseOptions{SpaceBeforeUnit: true, LongUnits: true, Locale: testGerman{}},
+			Expected: Whilst{Years: 1, Days: 2},
+		},
+		{
+			Input:    "0",
+			Options:  all,
+			Expected: Whilst{},
+		},
+	}
+
+	for _, item := range expected {
+		whl, err := ParseWith(item.Input, item.Options)
+		require.NoError(t, err, "input: %v", item.Input)
+		require.Equal(t, item.Expected, whl, "input: %v", item.Input)
+	}
+}
+
+func TestParseWithError(t *testing.T) {
+	expected := []struct {
+		Input   string
+		Options ParseOptions
+		Err     error
+	}{
+		{"2 y", ParseOptions{}, ErrUnitUnspecified},
+		{"2Y", ParseOptions{}, ErrUnexpectedUnit},
+		{"2years", ParseOptions{}, ErrUnexpectedUnit},
+		{"2Years", ParseOptions{LongUnits: true}, ErrUnexpectedUnit},
+		{"1 Jahr", ParseOptions{SpaceBeforeUnit: true, LongUnits: true, Locale: testGerman{}}, nil},
+		{"1 jahr", ParseOptions{SpaceBeforeUnit: true, LongUnits: true, Locale: testGerman{}}, ErrUnexpectedUnit},
+		{"1 2h", ParseOptions{SpaceBeforeUnit: true}, ErrUnitUnspecified},
+		{"1 .5h", ParseOptions{SpaceBeforeUnit: true}, ErrUnitUnspecified},
+		{"1h 2", ParseOptions{SpaceBeforeUnit: true}, ErrUnitUnspecified},
+		{"1h 2 ", ParseOptions{SpaceBeforeUnit: true}, ErrUnitUnspecified},
+		{"1.5 days", ParseOptions{SpaceBeforeUnit: true, LongUnits: true}, ErrOnlyInteger},
+		{"1 fortnight", ParseOptions{SpaceBeforeUnit: true, LongUnits: true}, ErrUnexpectedUnit},
+		{"65536 years", ParseOptions{SpaceBeforeUnit: true, LongUnits: true}, safe.ErrOverflow},
+	}
+
+	for _, item := range expected {
+		whl, err := ParseWith(item.Input, item.Options)
+
+		if item.Err == nil {
+			require.NoError(t, err, "input: %v", item.Input)
+			continue
+		}
+
+		require.ErrorIs(t, err, item.Err, "input: %v", item.Input)
+		require.Equal(t, Whilst{}, whl, "input: %v", item.Input)
+
+		var parseErr *ParseError
+
+		require.ErrorAs(t, err, &parseErr, "input: %v", item.Input)
+	}
+}
+
 func TestCompatibility(t *testing.T) {
 	inputs := []string{
 		"-9223372036854775808ns",
@@ -140,6 +275,32 @@ func TestCompatibility(t *testing.T) {
 	}
 }
 
+func TestParseFraction(t *testing.T) {
+	inputs := map[string]time.Duration{
+		"1.000000001h":                  3600000003600,
+		"0.000000000001h":               3,
+		"-0.000000000001h":              -3,
+		"0.0000000000001h":              0,
+		"0.2500000000025h":              900000000009,
+		"0.9657068959291666579h":        3476544825345,
+		"1.5m":                          90 * time.Second,
+		"0.0000000015s":                 1,
+		"0.9999999999999999999s":        time.Second,
+		"0.00000000000000000000000001h": 0,
+		"1.9223372036854775808ns":       1,
+	}
+
+	for input, expected := range inputs {
+		whl, err := Parse(input)
+		require.NoError(t, err, "input: %v", input)
+		require.Equal(t, Whilst{Nano: expected}.normalize(), whl, "input: %v", input)
+
+		duration, err := time.ParseDuration(input)
+		require.NoError(t, err, "input: %v", input)
+		require.Equal(t, duration, expected, "input: %v", input)
+	}
+}
+
 func TestCompatibilityError(t *testing.T) {
 	inputs := []string{
 		"",
@@ -173,6 +334,14 @@ func TestManualSet(t *testing.T) {
 	whl = Whilst{Nano: 1e9}
 	require.Equal(t, "1s", whl.String())
 	require.Equal(t, "0001-01-01 00:00:01 +0000 UTC", whl.When(time.Time{}).String())
+
+	whl = Whilst{Weeks: 2, Days: 3}
+	require.Equal(t, "2w3d", whl.String())
+	require.Equal(t, "0001-01-18 00:00:00 +0000 UTC", whl.When(time.Time{}).String())
+
+	whl = Whilst{Weeks: 1, Negative: true}
+	require.Equal(t, "-1w", whl.String())
+	require.Equal(t, "0000-12-25 00:00:00 +0000 UTC", whl.When(time.Time{}).String())
 }
 
 func FuzzPanic(f *testing.F) {
@@ -218,6 +387,7 @@ func FuzzManualSet(f *testing.F) {
 		uint16(math.MaxUint8),
 		uint16(math.MaxUint8),
 		uint16(math.MaxUint8),
+		uint16(math.MaxUint8),
 		true,
 	)
 
@@ -226,6 +396,7 @@ func FuzzManualSet(f *testing.F) {
 		uint16(math.MaxUint8),
 		uint16(math.MaxUint8),
 		uint16(math.MaxUint8),
+		uint16(math.MaxUint8),
 		false,
 	)
 
@@ -234,6 +405,7 @@ func FuzzManualSet(f *testing.F) {
 		uint16(math.MaxUint8),
 		uint16(math.MaxUint8),
 		uint16(math.MaxUint8),
+		uint16(math.MaxUint8),
 		true,
 	)
 
@@ -242,14 +414,16 @@ func FuzzManualSet(f *testing.F) {
 		uint16(math.MaxUint8),
 		uint16(math.MaxUint8),
 		uint16(math.MaxUint8),
+		uint16(math.MaxUint8),
 		false,
 	)
 
 	f.Fuzz(
-		func(t *testing.T, nano int64, days, months, years uint16, negative bool) {
+		func(t *testing.T, nano int64, days, weeks, months, years uint16, negative bool) {
 			origin := Whilst{
 				Nano:     time.Duration(nano),
 				Days:     days,
+				Weeks:    weeks,
 				Months:   months,
 				Years:    years,
 				Negative: negative,
@@ -267,6 +441,32 @@ func FuzzManualSet(f *testing.F) {
 	)
 }
 
+func FuzzParseWith(f *testing.F) {
+	f.Add(" - 2y 3mo 1w 10d 23.5h 59.5m 58.01003001s 10ms 30µs 10ns")
+	f.Add("2 Years 3 months 1 day 1.5 hours")
+	f.Add("1 2h")
+
+	all := ParseOptions{SpaceBeforeUnit: true, CaseInsensitive: true, LongUnits: true}
+
+	f.Fuzz(
+		func(t *testing.T, input string) {
+			strict, strictErr := Parse(input)
+
+			whl, err := ParseWith(input, ParseOptions{})
+			require.Equal(t, strictErr, err)
+			require.Equal(t, strict, whl)
+
+			if strictErr != nil {
+				return
+			}
+
+			whl, err = ParseWith(input, all)
+			require.NoError(t, err)
+			require.Equal(t, strict, whl)
+		},
+	)
+}
+
 func FuzzCompatibility(f *testing.F) {
 	f.Add("-23.5h59.5m58.01003001s10ms30µs10ns")
 	f.Add("9223372036854775807ns")
@@ -287,6 +487,86 @@ func FuzzCompatibility(f *testing.F) {
 	)
 }
 
+func FuzzFraction(f *testing.F) {
+	f.Add(uint32(1), uint64(1), uint8(9), uint8(5), false)
+	f.Add(uint32(0), uint64(25000000000250), uint8(14), uint8(5), false)
+	f.Add(uint32(0), uint64(9657068959291666579), uint8(19), uint8(5), true)
+	f.Add(uint32(0), uint64(15), uint8(10), uint8(3), false)
+
+	units := []struct {
+		Name      string
+		Dimension time.Duration
+	}{
+		{"ns", time.Nanosecond},
+		{"µs", time.Microsecond},
+		{"ms", time.Millisecond},
+		{"s", time.Second},
+		{"m", time.Minute},
+		{"h", time.Hour},
+	}
+
+	f.Fuzz(
+		func(t *testing.T, integer uint32, numerator uint64, digits, unit uint8, negative bool) {
+			const maxDigits = 18
+
+			if digits == 0 || digits > maxDigits {
+				return
+			}
+
+			unitID := int(unit) % len(units)
+
+			scale := new(big.Int).Exp(big.NewInt(consts.DecimalBase), big.NewInt(int64(digits)), nil)
+			fraction := new(big.Int).SetUint64(numerator)
+
+			if fraction.Cmp(scale) >= 0 {
+				return
+			}
+
+			input := fmt.Sprintf(
+				"%d.%0*d%s",
+				integer,
+				digits,
+				numerator,
+				units[unitID].Name,
+			)
+
+			if negative {
+				input = "-" + input
+			}
+
+			expected, err := time.ParseDuration(input)
+			if err != nil {
+				_, err = Parse(input)
+				require.Error(t, err, "input: %v", input)
+
+				return
+			}
+
+			whl, err := Parse(input)
+			require.NoError(t, err, "input: %v", input)
+
+			exact := new(big.Int).SetUint64(uint64(integer))
+			exact.Mul(exact, scale)
+			exact.Add(exact, fraction)
+			exact.Mul(exact, big.NewInt(int64(units[unitID].Dimension)))
+			exact.Quo(exact, scale)
+
+			if negative {
+				exact.Neg(exact)
+			}
+
+			actual := whl.Duration(time.Time{})
+
+			require.Equal(t, expected, actual, "input: %v", input)
+			require.Equal(t, expected.String(), whl.String(), "input: %v", input)
+
+			// Rounding of float64 arithmetic reproduced for the compatibility with
+			// time.ParseDuration affects the result by no more than one nanosecond
+			require.LessOrEqual(t, (actual - time.Duration(exact.Int64())).Abs(), time.Nanosecond, "input: %v", input)
+		},
+	)
+}
+
 func FuzzError(f *testing.F) {
 	f.Add("-23.5h59.5m58.01003001s10ms30µs10ns")
 	f.Add("9223372036854775807ns")